@@ -0,0 +1,244 @@
+package linux
+
+import (
+	"math/bits"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+// Allocator of memory that is not scanned by the garbage collector, suitable
+// for large off-heap caches. Allocations are rounded up to a power of two
+// size class and carved out of page-granular slabs, which are in turn carved
+// out of arenas, mapped with [API.MapIntoMemory] or, if UseHeap is set,
+// taken from [API.Heap]. Allocations larger than the largest size class are
+// mapped individually. Memory must not hold pointers to the Go heap and must
+// be returned with [Allocator.Free]. Allocators are safe for concurrent use.
+type Allocator struct {
+	Linux     *API
+	Flags     Map  // additional flags for arena mappings, for example [MapHugeTables].
+	ArenaSize int  // size of each arena, rounded up to a multiple of the slab size, defaults to 2 MiB.
+	UseHeap   bool // take arenas from [API.Heap] instead of mapping them, see [API.Heap] for when this is unsafe.
+
+	mu     sync.Mutex
+	free   [allocatorClasses]unsafe.Pointer // free list of each size class, linked through the first word.
+	arenas []allocatorArena
+	large  map[unsafe.Pointer]MappedMemory
+	stats  AllocatorStats
+}
+
+// AllocatorStats reports the memory used by an [Allocator].
+type AllocatorStats struct {
+	Allocations int   // number of allocations made.
+	Frees       int   // number of allocations freed.
+	InUse       Bytes // bytes of allocations that have not been freed, rounded up to their size class.
+	Reserved    Bytes // bytes reserved from the system, for arenas and large allocations.
+	Arenas      int   // number of arenas reserved.
+}
+
+const (
+	allocatorMinimum = 16       // size of the smallest size class.
+	allocatorClasses = 12       // number of size classes, up to 32 KiB.
+	allocatorSlab    = 64 << 10 // size of a slab, a multiple of every supported page size.
+	allocatorDefault = 2 << 20  // default size of an arena, the size of a huge page.
+)
+
+// allocatorArena is a contiguous region from which slabs are carved.
+type allocatorArena struct {
+	base    unsafe.Pointer
+	size    int
+	used    int
+	mapped  MappedMemory // nil for arenas taken from the heap.
+	classes []uint8      // size class of each slab carved so far.
+}
+
+// allocatorClass returns the size class for an allocation of size bytes, or
+// allocatorClasses if it is too large for any size class.
+func allocatorClass(size int) int {
+	if size <= allocatorMinimum {
+		return 0
+	}
+	return min(bits.Len(uint(size-1))-bits.Len(allocatorMinimum-1), allocatorClasses)
+}
+
+// Allocate returns size bytes of zeroed memory, with a capacity of the size
+// class it was allocated from, which must be passed to [Allocator.Free]
+// unchanged.
+func (a *Allocator) Allocate(size int) ([]byte, error) {
+	if size <= 0 {
+		return nil, new(MapError).Types().Invalid
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var class = allocatorClass(size)
+	if class == allocatorClasses {
+		return a.allocateLarge(size)
+	}
+	var capacity = allocatorMinimum << class
+	if a.free[class] == nil {
+		if err := a.refill(class); err != nil {
+			return nil, err
+		}
+	}
+	var ptr = a.free[class]
+	a.free[class] = *(*unsafe.Pointer)(ptr)
+	var buf = unsafe.Slice((*byte)(ptr), capacity)
+	clear(buf)
+	a.stats.Allocations++
+	a.stats.InUse += Bytes(capacity)
+	return buf[:size], nil
+}
+
+// allocateLarge maps an allocation that is too large for any size class.
+func (a *Allocator) allocateLarge(size int) ([]byte, error) {
+	var page = syscall.Getpagesize()
+	var length = (size + page - 1) &^ (page - 1)
+	mapped, err := a.Linux.MapIntoMemory(nil, length, MemoryAllowReads|MemoryAllowWrites, MapPrivate, MapAnonymous, -1, 0)
+	if err != nil {
+		return nil, err
+	}
+	if a.large == nil {
+		a.large = make(map[unsafe.Pointer]MappedMemory)
+	}
+	a.large[mapped.UnsafePointer()] = mapped
+	a.stats.Allocations++
+	a.stats.InUse += Bytes(length)
+	a.stats.Reserved += Bytes(length)
+	return unsafe.Slice((*byte)(mapped.UnsafePointer()), length)[:size], nil
+}
+
+// refill the free list of the given size class with a new slab.
+func (a *Allocator) refill(class int) error {
+	var arena *allocatorArena
+	if n := len(a.arenas); n > 0 && a.arenas[n-1].size-a.arenas[n-1].used >= allocatorSlab {
+		arena = &a.arenas[n-1]
+	} else {
+		if err := a.grow(); err != nil {
+			return err
+		}
+		arena = &a.arenas[len(a.arenas)-1]
+	}
+	var slab = unsafe.Add(arena.base, arena.used)
+	arena.used += allocatorSlab
+	arena.classes = append(arena.classes, uint8(class))
+	var capacity = allocatorMinimum << class
+	for offset := allocatorSlab - capacity; offset >= 0; offset -= capacity {
+		var ptr = unsafe.Add(slab, offset)
+		*(*unsafe.Pointer)(ptr) = a.free[class]
+		a.free[class] = ptr
+	}
+	return nil
+}
+
+// grow reserves a new arena.
+func (a *Allocator) grow() error {
+	var size = allocatorDefault
+	if a.ArenaSize > 0 {
+		size = (a.ArenaSize + allocatorSlab - 1) &^ (allocatorSlab - 1)
+	}
+	var arena = allocatorArena{size: size}
+	if a.UseHeap {
+		end, err := a.Linux.Heap(nil)
+		if err != nil {
+			return err
+		}
+		var page = uintptr(syscall.Getpagesize())
+		var base = unsafe.Add(end, (page-uintptr(end)%page)%page)
+		if _, err := a.Linux.Heap(unsafe.Add(base, size)); err != nil {
+			return err
+		}
+		if now, err := a.Linux.Heap(nil); err != nil || uintptr(now) < uintptr(base)+uintptr(size) {
+			return new(HeapError).Types().OutOfMemory
+		}
+		arena.base = base
+	} else {
+		mapped, err := a.Linux.MapIntoMemory(nil, size, MemoryAllowReads|MemoryAllowWrites, MapPrivate, MapAnonymous|a.Flags, -1, 0)
+		if err != nil {
+			return err
+		}
+		arena.base, arena.mapped = mapped.UnsafePointer(), mapped
+	}
+	a.arenas = append(a.arenas, arena)
+	a.stats.Arenas++
+	a.stats.Reserved += Bytes(size)
+	return nil
+}
+
+// Free returns memory allocated by [Allocator.Allocate], which must not be used
+// afterwards. Large allocations are unmapped immediately, the rest are kept for
+// reuse until the allocator is closed.
+func (a *Allocator) Free(buf []byte) error {
+	var ptr = unsafe.Pointer(unsafe.SliceData(buf))
+	var class = allocatorClass(cap(buf))
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if class == allocatorClasses {
+		mapped, ok := a.large[ptr]
+		if !ok {
+			return new(MapError).Types().Invalid
+		}
+		delete(a.large, ptr)
+		a.stats.Frees++
+		a.stats.InUse -= Bytes(mapped.Len())
+		a.stats.Reserved -= Bytes(mapped.Len())
+		return mapped.Close()
+	}
+	if cap(buf) != allocatorMinimum<<class || !a.owns(ptr, class) {
+		return new(MapError).Types().Invalid
+	}
+	*(*unsafe.Pointer)(ptr) = a.free[class]
+	a.free[class] = ptr
+	a.stats.Frees++
+	a.stats.InUse -= Bytes(cap(buf))
+	return nil
+}
+
+// owns reports whether ptr is the start of an allocation of the size class,
+// within a slab of that class in one of the arenas of the allocator.
+func (a *Allocator) owns(ptr unsafe.Pointer, class int) bool {
+	for _, arena := range a.arenas {
+		if uintptr(ptr) >= uintptr(arena.base) && uintptr(ptr) < uintptr(arena.base)+uintptr(arena.used) {
+			var offset = uintptr(ptr) - uintptr(arena.base)
+			return int(arena.classes[offset/allocatorSlab]) == class && offset%(allocatorMinimum<<class) == 0
+		}
+	}
+	return false
+}
+
+// Stats returns the current statistics of the allocator.
+func (a *Allocator) Stats() AllocatorStats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.stats
+}
+
+// Close releases all of the memory reserved by the allocator, which invalidates
+// any allocations that have not been freed. Heap arenas are only released if
+// the heap has not grown past them since.
+func (a *Allocator) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var errs error
+	for ptr, mapped := range a.large {
+		if err := mapped.Close(); err != nil && errs == nil {
+			errs = err
+		}
+		delete(a.large, ptr)
+	}
+	for i := len(a.arenas) - 1; i >= 0; i-- {
+		var arena = a.arenas[i]
+		if arena.mapped != nil {
+			if err := arena.mapped.Close(); err != nil && errs == nil {
+				errs = err
+			}
+			continue
+		}
+		if end, err := a.Linux.Heap(nil); err == nil && end == unsafe.Add(arena.base, arena.size) {
+			a.Linux.Heap(arena.base)
+		}
+	}
+	a.arenas = nil
+	a.free = [allocatorClasses]unsafe.Pointer{}
+	a.stats = AllocatorStats{Allocations: a.stats.Allocations, Frees: a.stats.Frees}
+	return errs
+}
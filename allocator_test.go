@@ -0,0 +1,107 @@
+package linux_test
+
+import (
+	"errors"
+	"testing"
+	"unsafe"
+
+	"verbose.style/linux"
+)
+
+func TestAllocator(t *testing.T) {
+	for name, alloc := range map[string]*linux.Allocator{
+		"Native": {Linux: linux.Native()},
+		"Memory": {Linux: linux.Memory()},
+		"Heap":   {Linux: linux.Memory(), UseHeap: true, ArenaSize: 256 << 10},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer alloc.Close()
+			var allocations [][]byte
+			for _, size := range []int{1, 16, 17, 100, 4096, 5000, 32 << 10, 100 << 10} {
+				buf, err := alloc.Allocate(size)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(buf) != size || cap(buf) < size {
+					t.Fatalf("allocated len %d cap %d for %d", len(buf), cap(buf), size)
+				}
+				for i := range buf {
+					if buf[i] != 0 {
+						t.Fatalf("allocation of %d is not zeroed", size)
+					}
+					buf[i] = byte(i)
+				}
+				allocations = append(allocations, buf)
+			}
+			var stats = alloc.Stats()
+			if stats.Allocations != 8 || stats.Frees != 0 || stats.InUse <= 0 || stats.Reserved < stats.InUse || stats.Arenas < 1 {
+				t.Fatalf("unexpected stats %+v", stats)
+			}
+			for _, buf := range allocations {
+				if err := alloc.Free(buf); err != nil {
+					t.Fatal(err)
+				}
+			}
+			stats = alloc.Stats()
+			if stats.Frees != 8 || stats.InUse != 0 {
+				t.Fatalf("unexpected stats after free %+v", stats)
+			}
+			reused, err := alloc.Allocate(100)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if &reused[:1][0] != &allocations[3][:1][0] {
+				t.Fatal("freed memory was not reused")
+			}
+			for i := range reused {
+				if reused[i] != 0 {
+					t.Fatal("reused memory was not zeroed")
+				}
+			}
+			if err := alloc.Free(make([]byte, 64)); !errors.Is(err, new(linux.MapError).Types().Invalid) {
+				t.Fatalf("expected invalid free, got %v", err)
+			}
+			// within a slab of the allocator, but not the start of an allocation
+			// of the size class of the capacity.
+			var inside = unsafe.Slice(&reused[:cap(reused)][16], 128)
+			if err := alloc.Free(inside); !errors.Is(err, new(linux.MapError).Types().Invalid) {
+				t.Fatalf("expected invalid free of a misaligned allocation, got %v", err)
+			}
+			if err := alloc.Free(unsafe.Slice(&reused[:1][0], 64)); !errors.Is(err, new(linux.MapError).Types().Invalid) {
+				t.Fatalf("expected invalid free of an allocation of another size class, got %v", err)
+			}
+			if _, err := alloc.Allocate(0); err == nil {
+				t.Fatal("expected an error for an empty allocation")
+			}
+		})
+	}
+}
+
+func TestAllocatorHugeTables(t *testing.T) {
+	var alloc = linux.Allocator{Linux: linux.Native(), Flags: linux.MapHugeTables}
+	defer alloc.Close()
+	buf, err := alloc.Allocate(64)
+	if err != nil {
+		var mapError linux.MapError
+		if errors.As(err, &mapError) {
+			t.Skip("huge pages are not available:", err)
+		}
+		t.Fatal(err)
+	}
+	buf[0] = 1
+	if err := alloc.Free(buf); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func BenchmarkAllocator(b *testing.B) {
+	var alloc = linux.Allocator{Linux: linux.Native()}
+	defer alloc.Close()
+	for range b.N {
+		buf, err := alloc.Allocate(128)
+		if err != nil {
+			b.Fatal(err)
+		}
+		alloc.Free(buf)
+	}
+}
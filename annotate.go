@@ -0,0 +1,255 @@
+package linux
+
+import (
+	"strconv"
+	"time"
+	"unsafe"
+)
+
+// OperationError wraps an error returned by an [API] function with the context
+// of the call that failed, much like [io/fs.PathError]. The wrapped error can
+// still be matched with [errors.As] and [errors.Is].
+type OperationError[E error] struct {
+	Operation string         // name of the [API] function, for example "Open".
+	Path      Path           // path that the operation was performed on, if any.
+	File      FileDescriptor // file that the operation was performed on, or -1.
+	Flags     []any          // flags passed to the operation, for example [FileCreationFlags].
+	Err       E
+}
+
+func (e *OperationError[E]) Error() string {
+	if e.Path != "" {
+		return e.Operation + " " + string(e.Path) + ": " + e.Err.Error()
+	}
+	if e.File >= 0 {
+		return e.Operation + " " + strconv.Itoa(int(e.File)) + ": " + e.Err.Error()
+	}
+	return e.Operation + ": " + e.Err.Error()
+}
+
+func (e *OperationError[E]) Unwrap() error { return e.Err }
+
+// Annotate returns an [API] that wraps the typed errors returned by api in an
+// [OperationError], so that they describe the operation, path and file that
+// failed. Calls that api does not implement are left nil. Wrapping
+// allocates, so use api directly on hot paths.
+func Annotate(api *API) *API {
+	var annotated = new(API)
+	*annotated = *api
+	if api.Read != nil {
+		annotated.Read = func(fd FileDescriptor, buf []byte) (Bytes, error) {
+			n, err := api.Read(fd, buf)
+			return n, annotate[ReadError](err, "Read", "", fd)
+		}
+	}
+	if api.Write != nil {
+		annotated.Write = func(fd FileDescriptor, buf []byte) (Bytes, error) {
+			n, err := api.Write(fd, buf)
+			return n, annotate[WriteError](err, "Write", "", fd)
+		}
+	}
+	if api.Open != nil {
+		annotated.Open = func(name Path, mode FileAccessMode, flag FileCreationFlags, status FileStatusFlags, perm FilePermissions) (File, error) {
+			file, err := api.Open(name, mode, flag, status, perm)
+			return File{Linux: annotated, Descriptor: file.Descriptor}, annotate[OpenError](err, "Open", name, -1, mode, flag, status, perm)
+		}
+	}
+	if api.Close != nil {
+		annotated.Close = func(fd FileDescriptor) error {
+			return annotate[CloseError](api.Close(fd), "Close", "", fd)
+		}
+	}
+	if api.Stat != nil {
+		annotated.Stat = func(name Path) (FileHeader, error) {
+			header, err := api.Stat(name)
+			return header, annotate[StatError](err, "Stat", name, -1)
+		}
+	}
+	if api.StatFile != nil {
+		annotated.StatFile = func(fd FileDescriptor) (FileHeader, error) {
+			header, err := api.StatFile(fd)
+			return header, annotate[StatError](err, "StatFile", "", fd)
+		}
+	}
+	if api.StatLink != nil {
+		annotated.StatLink = func(name Path) (FileHeader, error) {
+			header, err := api.StatLink(name)
+			return header, annotate[StatError](err, "StatLink", name, -1)
+		}
+	}
+	if api.Poll != nil {
+		annotated.Poll = func(files []FileToPoll, timeout time.Duration) (int, error) {
+			n, err := api.Poll(files, timeout)
+			return n, annotate[PollError](err, "Poll", "", -1)
+		}
+	}
+	if api.Seek != nil {
+		annotated.Seek = func(fd FileDescriptor, offset int64, whence Seek) (int64, error) {
+			n, err := api.Seek(fd, offset, whence)
+			return n, annotate[SeekError](err, "Seek", "", fd, whence)
+		}
+	}
+	if api.MapIntoMemory != nil {
+		annotated.MapIntoMemory = func(addr unsafe.Pointer, length int, prot MemoryProtection, mtype MapType, flags Map, fd FileDescriptor, offset uintptr) (MappedMemory, error) {
+			mapped, err := api.MapIntoMemory(addr, length, prot, mtype, flags, fd, offset)
+			return mapped, annotate[MapError](err, "MapIntoMemory", "", fd, prot, mtype, flags)
+		}
+	}
+	if api.ProtectMemory != nil {
+		annotated.ProtectMemory = func(addr unsafe.Pointer, length int, prot MemoryProtection) error {
+			return annotate[ProtectMemoryError](api.ProtectMemory(addr, length, prot), "ProtectMemory", "", -1, prot)
+		}
+	}
+	if api.Heap != nil {
+		annotated.Heap = func(addr unsafe.Pointer) (unsafe.Pointer, error) {
+			ptr, err := api.Heap(addr)
+			return ptr, annotate[HeapError](err, "Heap", "", -1)
+		}
+	}
+	if api.ReadDirectory != nil {
+		annotated.ReadDirectory = func(fd FileDescriptor, buf []byte) (Bytes, error) {
+			n, err := api.ReadDirectory(fd, buf)
+			return n, annotate[ReadDirectoryError](err, "ReadDirectory", "", fd)
+		}
+	}
+	if api.MakeDirectory != nil {
+		annotated.MakeDirectory = func(name Path, perm FilePermissions) error {
+			return annotate[MakeDirectoryError](api.MakeDirectory(name, perm), "MakeDirectory", name, -1, perm)
+		}
+	}
+	if api.RemoveFile != nil {
+		annotated.RemoveFile = func(name Path) error {
+			return annotate[RemoveError](api.RemoveFile(name), "RemoveFile", name, -1)
+		}
+	}
+	if api.RemoveDirectory != nil {
+		annotated.RemoveDirectory = func(name Path) error {
+			return annotate[RemoveError](api.RemoveDirectory(name), "RemoveDirectory", name, -1)
+		}
+	}
+	if api.Rename != nil {
+		annotated.Rename = func(from, to Path, flags Rename) error {
+			return annotate[RenameError](api.Rename(from, to, flags), "Rename", from, -1, to, flags)
+		}
+	}
+	if api.LinkFile != nil {
+		annotated.LinkFile = func(fd FileDescriptor, name Path) error {
+			return annotate[LinkError](api.LinkFile(fd, name), "LinkFile", name, fd)
+		}
+	}
+	if api.Sync != nil {
+		annotated.Sync = func(fd FileDescriptor) error {
+			return annotate[SyncError](api.Sync(fd), "Sync", "", fd)
+		}
+	}
+	if api.CreateEvent != nil {
+		annotated.CreateEvent = func(initial uint32, flags EventFlags) (File, error) {
+			file, err := api.CreateEvent(initial, flags)
+			return File{Linux: annotated, Descriptor: file.Descriptor}, annotate[EventError](err, "CreateEvent", "", -1, flags)
+		}
+	}
+	if api.Remap != nil {
+		annotated.Remap = func(addr unsafe.Pointer, length, newLength int, flags Remap, newAddr unsafe.Pointer) (unsafe.Pointer, error) {
+			ptr, err := api.Remap(addr, length, newLength, flags, newAddr)
+			return ptr, annotate[RemapError](err, "Remap", "", -1, flags)
+		}
+	}
+	if api.AdviseMemory != nil {
+		annotated.AdviseMemory = func(addr unsafe.Pointer, length int, advice MemoryAdvice) error {
+			return annotate[AdviseMemoryError](api.AdviseMemory(addr, length, advice), "AdviseMemory", "", -1, advice)
+		}
+	}
+	if api.LockMemory != nil {
+		annotated.LockMemory = func(addr unsafe.Pointer, length int, flags MemoryLock) error {
+			return annotate[LockMemoryError](api.LockMemory(addr, length, flags), "LockMemory", "", -1, flags)
+		}
+	}
+	if api.UnlockMemory != nil {
+		annotated.UnlockMemory = func(addr unsafe.Pointer, length int) error {
+			return annotate[LockMemoryError](api.UnlockMemory(addr, length), "UnlockMemory", "", -1)
+		}
+	}
+	if api.MemoryResidency != nil {
+		annotated.MemoryResidency = func(addr unsafe.Pointer, length int, pages []byte) error {
+			return annotate[MemoryResidencyError](api.MemoryResidency(addr, length, pages), "MemoryResidency", "", -1)
+		}
+	}
+	if api.SyncMemory != nil {
+		annotated.SyncMemory = func(addr unsafe.Pointer, length int, flags MemorySync) error {
+			return annotate[SyncMemoryError](api.SyncMemory(addr, length, flags), "SyncMemory", "", -1, flags)
+		}
+	}
+	if api.CreateMemoryFile != nil {
+		annotated.CreateMemoryFile = func(name Path, flags MemoryFileFlags) (File, error) {
+			file, err := api.CreateMemoryFile(name, flags)
+			return File{Linux: annotated, Descriptor: file.Descriptor}, annotate[MemoryFileError](err, "CreateMemoryFile", name, -1, flags)
+		}
+	}
+	if api.Truncate != nil {
+		annotated.Truncate = func(fd FileDescriptor, size int64) error {
+			return annotate[TruncateError](api.Truncate(fd, size), "Truncate", "", fd)
+		}
+	}
+	if api.WaitFutex != nil {
+		annotated.WaitFutex = func(addr *uint32, value uint32, timeout *Time, flags FutexFlags) error {
+			return annotate[FutexError](api.WaitFutex(addr, value, timeout, flags), "WaitFutex", "", -1, flags)
+		}
+	}
+	if api.WaitFutexBitset != nil {
+		annotated.WaitFutexBitset = func(addr *uint32, value uint32, deadline *Time, bitset uint32, flags FutexFlags) error {
+			return annotate[FutexError](api.WaitFutexBitset(addr, value, deadline, bitset, flags), "WaitFutexBitset", "", -1, flags)
+		}
+	}
+	if api.WakeFutex != nil {
+		annotated.WakeFutex = func(addr *uint32, count int, flags FutexFlags) (int, error) {
+			n, err := api.WakeFutex(addr, count, flags)
+			return n, annotate[FutexError](err, "WakeFutex", "", -1, flags)
+		}
+	}
+	if api.WakeFutexBitset != nil {
+		annotated.WakeFutexBitset = func(addr *uint32, count int, bitset uint32, flags FutexFlags) (int, error) {
+			n, err := api.WakeFutexBitset(addr, count, bitset, flags)
+			return n, annotate[FutexError](err, "WakeFutexBitset", "", -1, flags)
+		}
+	}
+	if api.RequeueFutex != nil {
+		annotated.RequeueFutex = func(addr *uint32, value uint32, wake, requeue int, to *uint32, flags FutexFlags) (int, error) {
+			n, err := api.RequeueFutex(addr, value, wake, requeue, to, flags)
+			return n, annotate[FutexError](err, "RequeueFutex", "", -1, flags)
+		}
+	}
+	if api.WaitFutexes != nil {
+		annotated.WaitFutexes = func(futexes []FutexToWait, deadline *Time, flags FutexFlags) (int, error) {
+			i, err := api.WaitFutexes(futexes, deadline, flags)
+			return i, annotate[FutexError](err, "WaitFutexes", "", -1, flags)
+		}
+	}
+	if api.ClockTime != nil {
+		annotated.ClockTime = func(clock Clock) (Time, error) {
+			t, err := api.ClockTime(clock)
+			return t, annotate[ClockError](err, "ClockTime", "", -1, clock)
+		}
+	}
+	if api.ClockResolution != nil {
+		annotated.ClockResolution = func(clock Clock) (Time, error) {
+			t, err := api.ClockResolution(clock)
+			return t, annotate[ClockError](err, "ClockResolution", "", -1, clock)
+		}
+	}
+	if api.Sleep != nil {
+		annotated.Sleep = func(clock Clock, flags Sleep, t Time) (Time, error) {
+			remaining, err := api.Sleep(clock, flags, t)
+			return remaining, annotate[SleepError](err, "Sleep", "", -1, clock, flags)
+		}
+	}
+	return annotated
+}
+
+// annotate err with the context of the operation, if it is an E.
+func annotate[E error](err error, operation string, name Path, fd FileDescriptor, flags ...any) error {
+	typed, ok := err.(E)
+	if !ok {
+		return err
+	}
+	return &OperationError[E]{Operation: operation, Path: name, File: fd, Flags: flags, Err: typed}
+}
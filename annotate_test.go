@@ -0,0 +1,48 @@
+package linux_test
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+
+	"verbose.style/linux"
+)
+
+func TestAnnotate(t *testing.T) {
+	var Linux = linux.Annotate(linux.Memory())
+
+	_, err := Linux.Open("/missing", linux.FileAccessReadOnly, 0, 0, 0)
+	if err == nil || err.Error() != "Open /missing: no such file or directory" {
+		t.Fatal(err)
+	}
+	var operation *linux.OperationError[linux.OpenError]
+	if !errors.As(err, &operation) || operation.Path != "/missing" || !errors.Is(err, fs.ErrNotExist) {
+		t.Fatal(err)
+	}
+	var openError linux.OpenError
+	if !errors.As(err, &openError) {
+		t.Fatal(err)
+	}
+
+	f, err := Linux.Open("/file", linux.FileAccessReadOnly, linux.FileCreateIfNeeded, 0, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte("x")); !errors.Is(err, new(linux.WriteError).Types().BadFile) || err.Error() != "Write 3: bad file descriptor" {
+		t.Fatal(err)
+	}
+}
+
+func TestAnnotatePartial(t *testing.T) {
+	var Linux = linux.Annotate(&linux.API{
+		Read: func(fd linux.FileDescriptor, buf []byte) (linux.Bytes, error) {
+			return 0, new(linux.ReadError).Types().WouldBlock
+		},
+	})
+	if Linux.Write != nil || Linux.Open != nil || Linux.Sleep != nil {
+		t.Fatal("calls that the inner API does not implement should stay nil")
+	}
+	if _, err := Linux.Read(3, nil); err == nil || err.Error() != "Read 3: resource temporarily unavailable" {
+		t.Fatal(err)
+	}
+}
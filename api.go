@@ -1,4 +1,10 @@
 // Package linux provides a VerboseStyle Linux system call API.
+//
+// [Native] is only available on Linux for 386, amd64, arm64 and riscv64, the
+// architectures that its struct layouts, system call numbers and flags are
+// generated and verified for, see internal/layoutgen and internal/flagsgen,
+// as are [Mutex] and [SharedQueue], which rely on it. The rest of the package,
+// such as [Memory], [Trace], [Faulty] and [Replay], builds everywhere.
 package linux
 
 import (
@@ -8,6 +14,9 @@ import (
 	"unsafe"
 )
 
+//go:generate go run ./internal/layoutgen
+//go:generate go run ./internal/flagsgen
+
 // API specification.
 type API struct {
 	// Read bytes from fd into the given buffer, returns the number of bytes read
@@ -46,45 +55,93 @@ type API struct {
 	// a general memory allocation mechanism. Unsafe to use when GODEBUG=sbrk=1. A
 	// nil pointer returns the current end of the heap.
 	Heap func(addr unsafe.Pointer) (unsafe.Pointer, error)
+	// ReadDirectory reads the next entries of the directory fd into the given
+	// buffer, in the format of getdents64(2), returns the number of bytes read
+	// which is zero at the end of the directory. See [File.ReadDirectory].
+	ReadDirectory func(fd FileDescriptor, buf []byte) (Bytes, error)
+	// MakeDirectory creates an empty directory at the given path.
+	MakeDirectory func(name Path, perm FilePermissions) error
+	// RemoveFile removes the name of a file from the file system, the file is
+	// deleted once it has no other names and is no longer open.
+	RemoveFile func(name Path) error
+	// RemoveDirectory removes the given directory, which must be empty.
+	RemoveDirectory func(name Path) error
+	// Rename the file located at from to to, replacing any file at to, unless
+	// flags specify otherwise.
+	Rename func(from, to Path, flags Rename) error
+	// LinkFile gives a new name to the open file fd, which may be a file opened
+	// with [FileTemporaryInside] that has no name yet.
+	LinkFile func(fd FileDescriptor, name Path) error
+	// Sync flushes the data and metadata of fd to the underlying storage device.
+	Sync func(fd FileDescriptor) error
+	// CreateEvent opens a file that holds a counter, starting at initial, which
+	// is added to by writing a uint64 and reset to zero by reading it. Reads
+	// block while the counter is zero, which makes events suitable to wake up an
+	// [API.Poll], see [PollContext].
+	CreateEvent func(initial uint32, flags EventFlags) (File, error)
+	// Remap grows or shrinks the memory mapped at addr to newLength, moving it
+	// if flags allow, to newAddr if [RemapExactAddress] is used. Returns the
+	// address of the mapping, which is invalid at addr if it has moved.
+	Remap func(addr unsafe.Pointer, length, newLength int, flags Remap, newAddr unsafe.Pointer) (unsafe.Pointer, error)
+	// AdviseMemory advises the kernel on how the memory at addr will be used,
+	// which must be page-aligned.
+	AdviseMemory func(addr unsafe.Pointer, length int, advice MemoryAdvice) error
+	// LockMemory locks the pages of the memory at addr in physical memory, so
+	// that they are not swapped out.
+	LockMemory func(addr unsafe.Pointer, length int, flags MemoryLock) error
+	// UnlockMemory undoes [API.LockMemory].
+	UnlockMemory func(addr unsafe.Pointer, length int) error
+	// MemoryResidency fills pages with a byte for each page of the memory at
+	// addr, which is page-aligned, with its lowest bit set if the page is
+	// resident in physical memory. Pages must have a byte for every page.
+	MemoryResidency func(addr unsafe.Pointer, length int, pages []byte) error
+	// SyncMemory flushes changes to the memory mapped at addr, which is
+	// page-aligned, back to the mapped file.
+	SyncMemory func(addr unsafe.Pointer, length int, flags MemorySync) error
+	// CreateMemoryFile opens an anonymous file that lives in memory, the name is
+	// only used for debugging. The file is empty, see [API.Truncate].
+	CreateMemoryFile func(name Path, flags MemoryFileFlags) (File, error)
+	// Truncate changes the size of fd to the given size, extending it with zeros.
+	Truncate func(fd FileDescriptor, size int64) error
+	// WaitFutex waits on the futex at addr for as long as it holds value, until
+	// it is woken by [API.WakeFutex] or the relative timeout, if not nil,
+	// expires. Waits can wake up spuriously, so the caller must check the value
+	// again. Futexes are shared with other processes mapping the same memory,
+	// unless [FutexPrivate] is used.
+	WaitFutex func(addr *uint32, value uint32, timeout *Time, flags FutexFlags) error
+	// WaitFutexBitset is [API.WaitFutex] with an absolute deadline, on the
+	// monotonic clock unless [FutexRealtimeClock] is used, that is only woken
+	// by [API.WakeFutexBitset] with a bitset that intersects with bitset.
+	WaitFutexBitset func(addr *uint32, value uint32, deadline *Time, bitset uint32, flags FutexFlags) error
+	// WakeFutex wakes up to count waiters on the futex at addr, returns the
+	// number of waiters woken.
+	WakeFutex func(addr *uint32, count int, flags FutexFlags) (int, error)
+	// WakeFutexBitset is [API.WakeFutex] that only wakes waiters whose bitset
+	// intersects with bitset, see [API.WaitFutexBitset].
+	WakeFutexBitset func(addr *uint32, count int, bitset uint32, flags FutexFlags) (int, error)
+	// RequeueFutex wakes up to wake waiters on the futex at addr and moves up
+	// to requeue of the rest to wait on the futex at to instead, as long as
+	// addr still holds value. Returns the number of waiters woken or moved.
+	RequeueFutex func(addr *uint32, value uint32, wake, requeue int, to *uint32, flags FutexFlags) (int, error)
+	// WaitFutexes waits on up to 128 futexes at once, until any of them is
+	// woken or the absolute deadline, if not nil, expires, returns the index of
+	// the futex that was woken. The deadline is on the monotonic clock unless
+	// [FutexRealtimeClock] is used.
+	WaitFutexes func(futexes []FutexToWait, deadline *Time, flags FutexFlags) (int, error)
+	// ClockTime returns the current time of the clock, as used for deadlines by
+	// [API.WaitFutexBitset] and [API.Sleep]. Clocks other than [ClockRealtime]
+	// start at a different point for each API, [Native] clocks usually count
+	// from boot, while [Memory] clocks count from its creation, so deadlines
+	// must be read from the API that waits on them.
+	ClockTime func(clock Clock) (Time, error)
+	// ClockResolution returns the resolution of the clock.
+	ClockResolution func(clock Clock) (Time, error)
+	// Sleep for the duration t, as measured by the clock, or until the clock
+	// reaches t if [SleepUntil] is used. An interrupted sleep returns the
+	// duration that remains, except with [SleepUntil].
+	Sleep func(clock Clock, flags Sleep, t Time) (Time, error)
 }
 
-type MemoryProtection int // use by [API.MapFileIntoMemory] abd [API.ProtectMemory]
-
-const (
-	MemoryNotAccessible  MemoryProtection = 0x0 // no access allowed.
-	MemoryAllowReads     MemoryProtection = 0x1 // read access allowed.
-	MemoryAllowWrites    MemoryProtection = 0x2 // write access allowed.
-	MemoryAllowExecution MemoryProtection = 0x4 // execute access allowed.
-	MemoryAllowAtomics   MemoryProtection = 0x8 // atomic operations allowed.
-)
-
-type MapType int // used by [API.MapFileIntoMemory]
-
-const (
-	MapShared              MapType = 0x01 // persist writes back to the file.
-	MapPrivate             MapType = 0x02 // copy-on-write memory.
-	MapSharedValidateFlags MapType = 0x03 // [MapShared] + validate flags.
-)
-
-type Map int // used by [API.MapFileIntoMemory]
-
-const (
-	MapAnonymous        Map = 0x20       // file must be -1, just allocate anonymous memory.
-	Map32Bit            Map = 0x40       // allocate memory in the first 4GB of the address space.
-	MapExactAddress     Map = 0x10       // addr must be page-aligned and will be used directly.
-	MapExactAddressOnce Map = 0x100000   // like [MapExactAddress] but goroutine-safe.
-	MapGrowsDown        Map = 0x100      // touching the first page will grow the mapping down by a single page/
-	MapHugeTables       Map = 0x40000    // use huge pages.
-	MapHuge2MB          Map = 0x54000000 // use 2MB huge pages.
-	MapHuge1GB          Map = 0x78000000 // use 1GB huge pages.
-	MapKeepAwayFromSwap Map = 0x2000     // lock the pages in physical memory (do not swap).
-	MapDoNotReserveSwap Map = 0x4000     // do not reserve swap space.
-	MapPopulate         Map = 0x8000     // eagerly load the file into the map.
-	MapStack            Map = 0x20000    // ensure memory is suitably setup to use for a stack.
-	MapSync             Map = 0x80000    // for files that support direct mapping of persistent memory.
-	MapUninitialized    Map = 0x4000000  // don't zero out pages, subject to the system secutiry policy.
-)
-
 // FileToPoll is used for [API.Poll] and configures which events to wait for.
 type FileToPoll struct {
 	_ structs.HostLayout
@@ -94,29 +151,17 @@ type FileToPoll struct {
 	Result Poll           // filled in by [API.Poll].
 }
 
-// Poll events that can be polled for.
-type Poll int16
-
-const (
-	PollHasReadAvailable        Poll = 0x001  // chance to try [File.Read]
-	PollHasPriority             Poll = 0x002  // priority has been passed to the file.
-	PollHasWriteAvailable       Poll = 0x004  // chance to try [File.Write]
-	PollHasPeerFinishedWriting  Poll = 0x2000 // remote socket peer shutdown write side.
-	PollHasPeerConnectionClosed Poll = 0x010  // remote socket peer closed connection.
-
-	PollHasError          Poll = 0x008 // only available in [FileToPoll.Result]
-	PollHasInvalidRequest Poll = 0x020 // only available in [FileToPoll.Result]
-)
-
-// Seek is used for [API.Seek] to specify where and whence to seek.
-type Seek int
+// FutexToWait is used for [API.WaitFutexes] and configures which futex to
+// wait on.
+type FutexToWait struct {
+	Futex *uint32    // futex to wait on.
+	Value uint32     // value that the futex must hold to wait.
+	Flags FutexFlags // only [FutexPrivate] is supported.
+}
 
 const (
-	SeekRelativeToStart Seek = 0 // seek relative to the start of the file.
-	SeekRelative        Seek = 1 // seek relative to the current offset of the file.
-	SeekRelativeToEnd   Seek = 2 // seek relative to the end of the file.
-	SeekHole            Seek = 4 // seek to the next hole greater than or equal to the given offset.
-	SeekData            Seek = 3 // seek to the next data greater than or equal to the given offset.
+	futexBitsetAny       = 0xffffffff // FUTEX_BITSET_MATCH_ANY
+	futexWaitMultipleMax = 128        // FUTEX_WAITV_MAX
 )
 
 type Bytes = int64
@@ -130,9 +175,8 @@ type IndexNode uint64
 type UserID uint32
 type GroupID uint32
 
+// Time since the epoch of a clock, or a duration, with nanosecond precision.
 type Time struct {
-	_ structs.HostLayout
-
 	Seconds int64
 	Nanos   int64
 }
@@ -146,4 +190,18 @@ type MappedMemory interface {
 	Len() int
 
 	UnsafePointer() unsafe.Pointer
+
+	// Remap the memory to newLength, see [API.Remap], which invalidates the
+	// memory in favour of the returned one, unless [RemapDoNotUnmap] is used.
+	Remap(newLength int, flags Remap) (MappedMemory, error)
+	// Advise the kernel on how the memory will be used, see [API.AdviseMemory].
+	Advise(advice MemoryAdvice) error
+	// Lock the memory, see [API.LockMemory].
+	Lock(flags MemoryLock) error
+	// Unlock the memory, see [API.UnlockMemory].
+	Unlock() error
+	// Residency of each page of the memory, see [API.MemoryResidency].
+	Residency(pages []byte) error
+	// Sync the memory to the mapped file, see [API.SyncMemory].
+	Sync(flags MemorySync) error
 }
@@ -1,6 +1,11 @@
+//go:build linux && (386 || amd64 || arm64 || riscv64)
+
 package linux
 
 import (
+	"runtime"
+	"strconv"
+	"structs"
 	"syscall"
 	"time"
 	"unsafe"
@@ -10,11 +15,11 @@ func Native() *API {
 	var os = new(API)
 	*os = API{
 		Read: func(f FileDescriptor, buf []byte) (Bytes, error) {
-			count, _, err := syscall.Syscall(syscall.SYS_READ, uintptr(f), uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)))
+			count, _, err := syscall.Syscall(syscall.SYS_READ, uintptr(f), uintptr(unsafe.Pointer(unsafe.SliceData(buf))), uintptr(len(buf)))
 			return Bytes(count), new(ReadError).parse(syscall.Errno(err))
 		},
 		Write: func(f FileDescriptor, buf []byte) (Bytes, error) {
-			count, _, err := syscall.Syscall(syscall.SYS_WRITE, uintptr(f), uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)))
+			count, _, err := syscall.Syscall(syscall.SYS_WRITE, uintptr(f), uintptr(unsafe.Pointer(unsafe.SliceData(buf))), uintptr(len(buf)))
 			return Bytes(count), new(WriteError).parse(syscall.Errno(err))
 		},
 		Open: func(path Path, access FileAccessMode, creation FileCreationFlags, status FileStatusFlags, perm FilePermissions) (File, error) {
@@ -23,37 +28,47 @@ func Native() *API {
 		},
 		Close: func(f FileDescriptor) error {
 			err := syscall.Close(int(f))
-			return new(CloseError).parse(err.(syscall.Errno))
+			return new(CloseError).parse(err)
 		},
 		Stat: func(path Path) (FileHeader, error) {
-			var header FileHeader
-			err := syscall.Stat(string(path), (*syscall.Stat_t)(unsafe.Pointer(&header)))
-			return header, new(StatError).parse(err)
+			var s stat
+			err := syscall.Stat(string(path), (*syscall.Stat_t)(unsafe.Pointer(&s)))
+			return makeFileHeader(&s), new(StatError).parse(err)
 		},
 		StatFile: func(fd FileDescriptor) (FileHeader, error) {
-			var header FileHeader
-			err := syscall.Fstat(int(fd), (*syscall.Stat_t)(unsafe.Pointer(&header)))
-			return header, new(StatError).parse(err)
+			var s stat
+			err := syscall.Fstat(int(fd), (*syscall.Stat_t)(unsafe.Pointer(&s)))
+			return makeFileHeader(&s), new(StatError).parse(err)
 		},
 		StatLink: func(name Path) (FileHeader, error) {
-			var header FileHeader
-			err := syscall.Lstat(string(name), (*syscall.Stat_t)(unsafe.Pointer(&header)))
-			return header, new(StatError).parse(err)
+			var s stat
+			err := syscall.Lstat(string(name), (*syscall.Stat_t)(unsafe.Pointer(&s)))
+			return makeFileHeader(&s), new(StatError).parse(err)
 		},
 		Poll: func(files []FileToPoll, timeout time.Duration) (int, error) {
 			if len(files) == 0 {
 				return 0, new(PollError).Types().Fault
 			}
-			i, _, err := syscall.Syscall(syscall.SYS_POLL, uintptr(unsafe.Pointer(&files[0])), uintptr(len(files)), uintptr(timeout))
-			return int(i), new(PollError).parse(err)
+			var ms = timeout.Milliseconds()
+			if timeout < 0 {
+				ms = -1
+			}
+			i, err := poll(files, int(ms))
+			return i, new(PollError).parse(err)
 		},
 		Seek: func(fd FileDescriptor, offset int64, whence Seek) (int64, error) {
-			o, err := syscall.Seek(int(fd), offset, int(whence))
-			return int64(o), new(SeekError).parse(err)
+			o, err := seek(fd, offset, whence)
+			return o, new(SeekError).parse(err)
 		},
 		MapIntoMemory: func(addr unsafe.Pointer, length int, prot MemoryProtection, mtype MapType, flags Map, fd FileDescriptor, offset uintptr) (MappedMemory, error) {
-			memory, err := syscall.Mmap(int(fd), int64(offset), length, int(prot), int(mtype)|int(flags))
-			return mmap{prot, memory}, new(MapError).parse(err)
+			if length <= 0 {
+				return nil, new(MapError).Types().Invalid
+			}
+			ptr, errno := mmap(addr, length, prot, int(mtype)|int(flags), fd, offset)
+			if errno != 0 {
+				return nil, new(MapError).parse(errno)
+			}
+			return mapping{prot, unsafe.Slice((*byte)(pointer(ptr)), length)}, nil
 		},
 		ProtectMemory: func(addr unsafe.Pointer, length int, prot MemoryProtection) error {
 			return new(ProtectMemoryError).parse(syscall.Mprotect(unsafe.Slice((*byte)(addr), length), int(prot)))
@@ -65,16 +80,216 @@ func Native() *API {
 			}
 			return nil, new(HeapError).parse(err)
 		},
+		ReadDirectory: func(fd FileDescriptor, buf []byte) (Bytes, error) {
+			n, err := syscall.Getdents(int(fd), buf)
+			return Bytes(n), new(ReadDirectoryError).parse(err)
+		},
+		MakeDirectory: func(name Path, perm FilePermissions) error {
+			return new(MakeDirectoryError).parse(syscall.Mkdir(string(name), uint32(perm)))
+		},
+		RemoveFile: func(name Path) error {
+			return new(RemoveError).parse(syscall.Unlink(string(name)))
+		},
+		RemoveDirectory: func(name Path) error {
+			return new(RemoveError).parse(syscall.Rmdir(string(name)))
+		},
+		Rename: func(from, to Path, flags Rename) error {
+			src, err := syscall.BytePtrFromString(string(from))
+			if err != nil {
+				return new(RenameError).parse(err)
+			}
+			dst, err := syscall.BytePtrFromString(string(to))
+			if err != nil {
+				return new(RenameError).parse(err)
+			}
+			var cwd = FileRelativeToWorkingDirectory
+			_, _, errno := syscall.Syscall6(sysRenameAt2, uintptr(cwd), uintptr(unsafe.Pointer(src)), uintptr(cwd), uintptr(unsafe.Pointer(dst)), uintptr(flags), 0)
+			return new(RenameError).parse(errno)
+		},
+		LinkFile: func(fd FileDescriptor, name Path) error {
+			return new(LinkError).parse(linkFile(fd, name))
+		},
+		Sync: func(fd FileDescriptor) error {
+			return new(SyncError).parse(syscall.Fsync(int(fd)))
+		},
+		CreateEvent: func(initial uint32, flags EventFlags) (File, error) {
+			fd, _, err := syscall.Syscall(syscall.SYS_EVENTFD2, uintptr(initial), uintptr(flags), 0)
+			return File{Linux: os, Descriptor: FileDescriptor(fd)}, new(EventError).parse(syscall.Errno(err))
+		},
+		Remap: func(addr unsafe.Pointer, length, newLength int, flags Remap, newAddr unsafe.Pointer) (unsafe.Pointer, error) {
+			ptr, errno := remap(addr, length, newLength, flags, newAddr)
+			if errno != 0 {
+				return nil, new(RemapError).parse(errno)
+			}
+			return pointer(ptr), nil
+		},
+		AdviseMemory: func(addr unsafe.Pointer, length int, advice MemoryAdvice) error {
+			_, _, errno := syscall.Syscall(syscall.SYS_MADVISE, uintptr(addr), uintptr(length), uintptr(advice))
+			return new(AdviseMemoryError).parse(errno)
+		},
+		LockMemory: func(addr unsafe.Pointer, length int, flags MemoryLock) error {
+			_, _, errno := syscall.Syscall(sysMemoryLock2, uintptr(addr), uintptr(length), uintptr(flags))
+			return new(LockMemoryError).parse(errno)
+		},
+		UnlockMemory: func(addr unsafe.Pointer, length int) error {
+			_, _, errno := syscall.Syscall(syscall.SYS_MUNLOCK, uintptr(addr), uintptr(length), 0)
+			return new(LockMemoryError).parse(errno)
+		},
+		MemoryResidency: func(addr unsafe.Pointer, length int, pages []byte) error {
+			var page = syscall.Getpagesize()
+			if len(pages) < (length+page-1)/page {
+				return new(MemoryResidencyError).Types().Invalid
+			}
+			_, _, errno := syscall.Syscall(syscall.SYS_MINCORE, uintptr(addr), uintptr(length), uintptr(unsafe.Pointer(unsafe.SliceData(pages))))
+			return new(MemoryResidencyError).parse(errno)
+		},
+		SyncMemory: func(addr unsafe.Pointer, length int, flags MemorySync) error {
+			_, _, errno := syscall.Syscall(syscall.SYS_MSYNC, uintptr(addr), uintptr(length), uintptr(flags))
+			return new(SyncMemoryError).parse(errno)
+		},
+		CreateMemoryFile: func(name Path, flags MemoryFileFlags) (File, error) {
+			ptr, err := syscall.BytePtrFromString(string(name))
+			if err != nil {
+				return File{Linux: os, Descriptor: -1}, new(MemoryFileError).parse(syscall.EINVAL)
+			}
+			fd, _, errno := syscall.Syscall(sysMemoryFileCreate, uintptr(unsafe.Pointer(ptr)), uintptr(flags), 0)
+			return File{Linux: os, Descriptor: FileDescriptor(fd)}, new(MemoryFileError).parse(errno)
+		},
+		Truncate: func(fd FileDescriptor, size int64) error {
+			return new(TruncateError).parse(syscall.Ftruncate(int(fd), size))
+		},
+		WaitFutex: func(addr *uint32, value uint32, timeout *Time, flags FutexFlags) error {
+			var limit *futexTime
+			if timeout != nil {
+				limit = &futexTime{Seconds: timeout.Seconds, Nanos: timeout.Nanos}
+			}
+			_, _, errno := syscall.Syscall6(sysFutex, uintptr(unsafe.Pointer(addr)), futexWait|uintptr(flags), uintptr(value), uintptr(unsafe.Pointer(limit)), 0, 0)
+			return new(FutexError).parse(errno)
+		},
+		WaitFutexBitset: func(addr *uint32, value uint32, deadline *Time, bitset uint32, flags FutexFlags) error {
+			var limit *futexTime
+			if deadline != nil {
+				limit = &futexTime{Seconds: deadline.Seconds, Nanos: deadline.Nanos}
+			}
+			_, _, errno := syscall.Syscall6(sysFutex, uintptr(unsafe.Pointer(addr)), futexWaitBitset|uintptr(flags), uintptr(value), uintptr(unsafe.Pointer(limit)), 0, uintptr(bitset))
+			return new(FutexError).parse(errno)
+		},
+		WakeFutex: func(addr *uint32, count int, flags FutexFlags) (int, error) {
+			n, _, errno := syscall.Syscall6(sysFutex, uintptr(unsafe.Pointer(addr)), futexWake|uintptr(flags), uintptr(count), 0, 0, 0)
+			return int(n), new(FutexError).parse(errno)
+		},
+		WakeFutexBitset: func(addr *uint32, count int, bitset uint32, flags FutexFlags) (int, error) {
+			n, _, errno := syscall.Syscall6(sysFutex, uintptr(unsafe.Pointer(addr)), futexWakeBitset|uintptr(flags), uintptr(count), 0, 0, uintptr(bitset))
+			return int(n), new(FutexError).parse(errno)
+		},
+		RequeueFutex: func(addr *uint32, value uint32, wake, requeue int, to *uint32, flags FutexFlags) (int, error) {
+			n, _, errno := syscall.Syscall6(sysFutex, uintptr(unsafe.Pointer(addr)), futexCompareRequeue|uintptr(flags), uintptr(wake), uintptr(requeue), uintptr(unsafe.Pointer(to)), uintptr(value))
+			return int(n), new(FutexError).parse(errno)
+		},
+		WaitFutexes: func(futexes []FutexToWait, deadline *Time, flags FutexFlags) (int, error) {
+			if len(futexes) == 0 || len(futexes) > futexWaitMultipleMax {
+				return -1, new(FutexError).Types().Invalid
+			}
+			var waiters = make([]futexWaiter, len(futexes))
+			for i, futex := range futexes {
+				waiters[i] = futexWaiter{
+					Value:   uint64(futex.Value),
+					Address: uint64(uintptr(unsafe.Pointer(futex.Futex))),
+					Flags:   futexSize32 | uint32(futex.Flags&FutexPrivate),
+				}
+			}
+			var clock = ClockMonotonic
+			if flags&FutexRealtimeClock != 0 {
+				clock = ClockRealtime
+			}
+			var timeout *futexTime
+			if deadline != nil {
+				timeout = &futexTime{Seconds: deadline.Seconds, Nanos: deadline.Nanos}
+			}
+			i, _, errno := syscall.Syscall6(sysFutexWaitMultiple, uintptr(unsafe.Pointer(&waiters[0])), uintptr(len(waiters)), 0, uintptr(unsafe.Pointer(timeout)), uintptr(clock), 0)
+			runtime.KeepAlive(futexes)
+			if errno != 0 {
+				return -1, new(FutexError).parse(errno)
+			}
+			return int(i), nil
+		},
+		ClockTime: func(clock Clock) (Time, error) {
+			if clock == ClockRealtime {
+				return TimeFrom(time.Now()), nil
+			}
+			var t futexTime
+			_, _, errno := syscall.RawSyscall(sysClockGetTime, uintptr(clock), uintptr(unsafe.Pointer(&t)), 0)
+			return t.time(), new(ClockError).parse(errno)
+		},
+		ClockResolution: func(clock Clock) (Time, error) {
+			var t futexTime
+			_, _, errno := syscall.RawSyscall(sysClockGetResolution, uintptr(clock), uintptr(unsafe.Pointer(&t)), 0)
+			return t.time(), new(ClockError).parse(errno)
+		},
+		Sleep: func(clock Clock, flags Sleep, t Time) (Time, error) {
+			var request, remaining = futexTime{Seconds: t.Seconds, Nanos: t.Nanos}, futexTime{}
+			_, _, errno := syscall.Syscall6(sysClockSleep, uintptr(clock), uintptr(flags), uintptr(unsafe.Pointer(&request)), uintptr(unsafe.Pointer(&remaining)), 0, 0)
+			return remaining.time(), new(SleepError).parse(errno)
+		},
 	}
 	return os
 }
 
-type mmap struct {
+// makeFileHeader converts the stat of the architecture into a [FileHeader].
+func makeFileHeader(s *stat) FileHeader {
+	return FileHeader{
+		Device:             s.Device,
+		IndexNode:          s.IndexNode,
+		HardLinks:          uint64(s.HardLinks),
+		Permissions:        s.Permissions,
+		User:               s.User,
+		Group:              s.Group,
+		Special:            s.Special,
+		Size:               s.Size,
+		BlockSize:          Bytes(s.BlockSize),
+		BlockCount:         s.BlockCount,
+		AccessedAt:         s.AccessedAt.time(),
+		ModifiedAt:         s.ModifiedAt.time(),
+		ModifiedMetadataAt: s.ModifiedMetadataAt.time(),
+	}
+}
+
+// time converts the timespec of the architecture into a [Time].
+func (t timespec) time() Time { return Time{Seconds: int64(t.Seconds), Nanos: int64(t.Nanos)} }
+
+const (
+	atSymbolicLinkFollow = 0x400  // AT_SYMLINK_FOLLOW
+	atEmptyPath          = 0x1000 // AT_EMPTY_PATH
+)
+
+// linkFile with linkat(2), files can only be linked by their descriptor with
+// CAP_DAC_READ_SEARCH, otherwise they are linked through /proc/self/fd.
+func linkFile(fd FileDescriptor, name Path) syscall.Errno {
+	var cwd = FileRelativeToWorkingDirectory
+	dst, err := syscall.BytePtrFromString(string(name))
+	if err != nil {
+		return syscall.EINVAL
+	}
+	var empty byte
+	_, _, errno := syscall.Syscall6(syscall.SYS_LINKAT, uintptr(fd), uintptr(unsafe.Pointer(&empty)), uintptr(cwd), uintptr(unsafe.Pointer(dst)), atEmptyPath, 0)
+	if errno != syscall.ENOENT && errno != syscall.EPERM {
+		return errno
+	}
+	src, err := syscall.BytePtrFromString("/proc/self/fd/" + strconv.Itoa(int(fd)))
+	if err != nil {
+		return syscall.EINVAL
+	}
+	_, _, errno = syscall.Syscall6(syscall.SYS_LINKAT, uintptr(cwd), uintptr(unsafe.Pointer(src)), uintptr(cwd), uintptr(unsafe.Pointer(dst)), atSymbolicLinkFollow, 0)
+	return errno
+}
+
+// mapping of memory by [API.MapIntoMemory].
+type mapping struct {
 	check MemoryProtection
 	slice []byte
 }
 
-func (m mmap) ReadAt(p []byte, off int64) (n int, err error) {
+func (m mapping) ReadAt(p []byte, off int64) (n int, err error) {
 	if m.check&MemoryAllowReads == 0 {
 		return 0, new(MapError).parse(syscall.EACCES)
 	}
@@ -82,7 +297,7 @@ func (m mmap) ReadAt(p []byte, off int64) (n int, err error) {
 	return len(p), nil
 }
 
-func (m mmap) WriteAt(p []byte, off int64) (n int, err error) {
+func (m mapping) WriteAt(p []byte, off int64) (n int, err error) {
 	if m.check&MemoryAllowWrites == 0 {
 		return 0, new(MapError).parse(syscall.EACCES)
 	}
@@ -90,17 +305,125 @@ func (m mmap) WriteAt(p []byte, off int64) (n int, err error) {
 	return len(p), nil
 }
 
-func (m mmap) Close() error {
-	return syscall.Munmap(m.slice)
+func (m mapping) Close() error {
+	_, _, errno := syscall.Syscall(syscall.SYS_MUNMAP, uintptr(m.UnsafePointer()), uintptr(len(m.slice)), 0)
+	return new(MapError).parse(errno)
 }
 
-func (m mmap) Len() int {
+func (m mapping) Len() int {
 	return len(m.slice)
 }
 
-func (m mmap) UnsafePointer() unsafe.Pointer {
+func (m mapping) UnsafePointer() unsafe.Pointer {
 	if len(m.slice) == 0 {
 		return nil
 	}
 	return unsafe.Pointer(&m.slice[0])
 }
+
+func (m mapping) Remap(newLength int, flags Remap) (MappedMemory, error) {
+	ptr, errno := remap(m.UnsafePointer(), len(m.slice), newLength, flags&^RemapExactAddress, nil)
+	if errno != 0 {
+		return nil, new(RemapError).parse(errno)
+	}
+	return mapping{m.check, unsafe.Slice((*byte)(pointer(ptr)), newLength)}, nil
+}
+
+func (m mapping) Advise(advice MemoryAdvice) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_MADVISE, uintptr(m.UnsafePointer()), uintptr(len(m.slice)), uintptr(advice))
+	return new(AdviseMemoryError).parse(errno)
+}
+
+func (m mapping) Lock(flags MemoryLock) error {
+	_, _, errno := syscall.Syscall(sysMemoryLock2, uintptr(m.UnsafePointer()), uintptr(len(m.slice)), uintptr(flags))
+	return new(LockMemoryError).parse(errno)
+}
+
+func (m mapping) Unlock() error {
+	return new(LockMemoryError).parse(syscall.Munlock(m.slice))
+}
+
+func (m mapping) Residency(pages []byte) error {
+	var page = syscall.Getpagesize()
+	if len(pages) < (len(m.slice)+page-1)/page {
+		return new(MemoryResidencyError).Types().Invalid
+	}
+	_, _, errno := syscall.Syscall(syscall.SYS_MINCORE, uintptr(m.UnsafePointer()), uintptr(len(m.slice)), uintptr(unsafe.Pointer(unsafe.SliceData(pages))))
+	return new(MemoryResidencyError).parse(errno)
+}
+
+func (m mapping) Sync(flags MemorySync) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_MSYNC, uintptr(m.UnsafePointer()), uintptr(len(m.slice)), uintptr(flags))
+	return new(SyncMemoryError).parse(errno)
+}
+
+// remap with mremap(2), see [pointer].
+func remap(addr unsafe.Pointer, length, newLength int, flags Remap, newAddr unsafe.Pointer) (uintptr, syscall.Errno) {
+	ptr, _, errno := syscall.Syscall6(syscall.SYS_MREMAP, uintptr(addr), uintptr(length), uintptr(newLength), uintptr(flags), uintptr(newAddr), 0)
+	return ptr, errno
+}
+
+// pointer to memory that the kernel mapped at addr, outside of the Go heap,
+// which is never moved or collected, so unlike an address of Go memory it
+// remains valid as a uintptr.
+func pointer(addr uintptr) unsafe.Pointer { return unsafe.Add(nil, addr) }
+
+const (
+	futexWait           = 0  // FUTEX_WAIT
+	futexWake           = 1  // FUTEX_WAKE
+	futexCompareRequeue = 4  // FUTEX_CMP_REQUEUE
+	futexWaitBitset     = 9  // FUTEX_WAIT_BITSET
+	futexWakeBitset     = 10 // FUTEX_WAKE_BITSET
+
+	futexSize32          = 0x2 // FUTEX2_SIZE_U32
+	sysFutexWaitMultiple = 449 // SYS_FUTEX_WAITV, the same on every architecture.
+)
+
+// futexWaiter is struct futex_waitv, see futex_waitv(2).
+type futexWaiter struct {
+	_ structs.HostLayout
+
+	Value   uint64
+	Address uint64
+	Flags   uint32
+	_       uint32
+}
+
+// futexTime is struct __kernel_timespec, which is 64-bit on every
+// architecture, unlike [timespec].
+type futexTime struct {
+	_ structs.HostLayout
+
+	Seconds int64
+	Nanos   int64
+}
+
+func (t futexTime) time() Time { return Time{Seconds: t.Seconds, Nanos: t.Nanos} }
+
+const sysOpenProcess = 434 // SYS_PIDFD_OPEN, the same on every architecture.
+
+// openProcess returns a pidfd(2) for the process, which becomes readable once
+// the process exits.
+func openProcess(pid int) (FileDescriptor, syscall.Errno) {
+	fd, _, errno := syscall.Syscall(sysOpenProcess, uintptr(pid), 0, 0)
+	if errno != 0 {
+		return -1, errno
+	}
+	return FileDescriptor(fd), 0
+}
+
+// robustListHead is struct robust_list_head, see set_robust_list(2).
+type robustListHead struct {
+	_ structs.HostLayout
+
+	Next        uintptr // first entry of the list, or the head itself.
+	FutexOffset int     // offset of the futex word from each entry.
+	Pending     uintptr // entry that is being locked or unlocked.
+}
+
+// setRobustList registers the robust futex list of the calling thread, whose
+// futexes the kernel releases when the thread exits.
+func setRobustList(head *robustListHead) syscall.Errno {
+	_, _, errno := syscall.RawSyscall(syscall.SYS_SET_ROBUST_LIST, uintptr(unsafe.Pointer(head)), unsafe.Sizeof(*head), 0)
+	return errno
+}
@@ -0,0 +1,78 @@
+package linux
+
+import (
+	"structs"
+	"syscall"
+	"unsafe"
+)
+
+// stat is struct stat64, as filled in by stat(2), see [makeFileHeader].
+type stat struct { //cc:stat64
+	_ structs.HostLayout
+
+	Device      DeviceID
+	_           uint16
+	_           [2]byte
+	_           uint32 // truncated [IndexNode].
+	Permissions FilePermissions
+	HardLinks   uint32
+	User        UserID
+	Group       GroupID
+	Special     DeviceID
+	_           uint16
+	_           [2]byte
+	Size        Bytes
+	BlockSize   int32
+	BlockCount  int64
+
+	AccessedAt         timespec
+	ModifiedAt         timespec
+	ModifiedMetadataAt timespec
+	IndexNode          IndexNode
+}
+
+// timespec is struct timespec, as filled in by stat(2), see [timespec.time].
+type timespec struct { //cc:timespec
+	_ structs.HostLayout
+
+	Seconds int32
+	Nanos   int32
+}
+
+// poll with poll(2), timeout is in milliseconds, negative waits forever.
+func poll(files []FileToPoll, timeout int) (int, syscall.Errno) {
+	n, _, errno := syscall.Syscall(syscall.SYS_POLL, uintptr(unsafe.Pointer(&files[0])), uintptr(len(files)), uintptr(timeout))
+	return int(n), errno
+}
+
+const sysRenameAt2 = 353 // SYS_RENAMEAT2
+
+const sysMemoryLock2 = 376 // SYS_MLOCK2
+
+const sysMemoryFileCreate = 356 // SYS_MEMFD_CREATE
+
+// clock_gettime64(2), clock_getres_time64(2) and clock_nanosleep_time64(2), as
+// the original calls take a [timespec] with 32-bit seconds.
+const (
+	sysClockGetTime       = 403 // SYS_CLOCK_GETTIME64
+	sysClockGetResolution = 406 // SYS_CLOCK_GETRES_TIME64
+	sysClockSleep         = 407 // SYS_CLOCK_NANOSLEEP_TIME64
+)
+
+const sysFutex = 422 // SYS_FUTEX_TIME64, as futex(2) takes a [timespec] with 32-bit seconds.
+
+// seek with _llseek(2), as lseek(2) only supports 32-bit offsets.
+func seek(fd FileDescriptor, offset int64, whence Seek) (int64, syscall.Errno) {
+	var result int64
+	_, _, errno := syscall.Syscall6(syscall.SYS__LLSEEK, uintptr(fd), uintptr(uint64(offset)>>32), uintptr(uint32(offset)), uintptr(unsafe.Pointer(&result)), uintptr(whence), 0)
+	return result, errno
+}
+
+// mmap with mmap2(2), which takes the offset in 4096 byte units, see [pointer].
+func mmap(addr unsafe.Pointer, length int, prot MemoryProtection, flags int, fd FileDescriptor, offset uintptr) (uintptr, syscall.Errno) {
+	if offset%4096 != 0 {
+		return 0, syscall.EINVAL
+	}
+	ptr, _, errno := syscall.Syscall6(syscall.SYS_MMAP2, uintptr(addr), uintptr(length), uintptr(prot), uintptr(flags), uintptr(fd), offset/4096)
+	return ptr, errno
+}
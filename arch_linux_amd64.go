@@ -0,0 +1,69 @@
+package linux
+
+import (
+	"structs"
+	"syscall"
+	"unsafe"
+)
+
+// stat is struct stat, as filled in by stat(2), see [makeFileHeader].
+type stat struct { //cc:stat
+	_ structs.HostLayout
+
+	Device      DeviceID
+	IndexNode   IndexNode
+	HardLinks   uint64
+	Permissions FilePermissions
+	User        UserID
+	Group       GroupID
+	_           int32
+	Special     DeviceID
+	Size        Bytes
+	BlockSize   Bytes
+	BlockCount  int64
+
+	AccessedAt         timespec
+	ModifiedAt         timespec
+	ModifiedMetadataAt timespec
+	_                  [3]int64
+}
+
+// timespec is struct timespec, as filled in by stat(2), see [timespec.time].
+type timespec struct { //cc:timespec
+	_ structs.HostLayout
+
+	Seconds int64
+	Nanos   int64
+}
+
+// poll with poll(2), timeout is in milliseconds, negative waits forever.
+func poll(files []FileToPoll, timeout int) (int, syscall.Errno) {
+	n, _, errno := syscall.Syscall(syscall.SYS_POLL, uintptr(unsafe.Pointer(&files[0])), uintptr(len(files)), uintptr(timeout))
+	return int(n), errno
+}
+
+const sysRenameAt2 = 316 // SYS_RENAMEAT2
+
+const sysMemoryLock2 = 325 // SYS_MLOCK2
+
+const sysMemoryFileCreate = 319 // SYS_MEMFD_CREATE
+
+const (
+	sysClockGetTime       = syscall.SYS_CLOCK_GETTIME
+	sysClockGetResolution = syscall.SYS_CLOCK_GETRES
+	sysClockSleep         = syscall.SYS_CLOCK_NANOSLEEP
+)
+
+const sysFutex = syscall.SYS_FUTEX
+
+// seek with lseek(2).
+func seek(fd FileDescriptor, offset int64, whence Seek) (int64, syscall.Errno) {
+	o, _, errno := syscall.Syscall(syscall.SYS_LSEEK, uintptr(fd), uintptr(offset), uintptr(whence))
+	return int64(o), errno
+}
+
+// mmap with mmap(2), see [pointer].
+func mmap(addr unsafe.Pointer, length int, prot MemoryProtection, flags int, fd FileDescriptor, offset uintptr) (uintptr, syscall.Errno) {
+	ptr, _, errno := syscall.Syscall6(syscall.SYS_MMAP, uintptr(addr), uintptr(length), uintptr(prot), uintptr(flags), uintptr(fd), offset)
+	return ptr, errno
+}
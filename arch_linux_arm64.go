@@ -0,0 +1,75 @@
+package linux
+
+import (
+	"structs"
+	"syscall"
+	"unsafe"
+)
+
+// stat is struct stat, as filled in by stat(2), see [makeFileHeader].
+type stat struct { //cc:stat
+	_ structs.HostLayout
+
+	Device      DeviceID
+	IndexNode   IndexNode
+	Permissions FilePermissions
+	HardLinks   uint32
+	User        UserID
+	Group       GroupID
+	Special     DeviceID
+	_           uint64
+	Size        Bytes
+	BlockSize   int32
+	_           int32
+	BlockCount  int64
+
+	AccessedAt         timespec
+	ModifiedAt         timespec
+	ModifiedMetadataAt timespec
+	_                  [2]int32
+}
+
+// timespec is struct timespec, as filled in by stat(2), see [timespec.time].
+type timespec struct { //cc:timespec
+	_ structs.HostLayout
+
+	Seconds int64
+	Nanos   int64
+}
+
+// poll with ppoll(2), as there is no poll(2), timeout is in milliseconds,
+// negative waits forever.
+func poll(files []FileToPoll, timeout int) (int, syscall.Errno) {
+	var limit *timespec
+	if timeout >= 0 {
+		limit = &timespec{Seconds: int64(timeout / 1000), Nanos: int64(timeout%1000) * 1e6}
+	}
+	n, _, errno := syscall.Syscall6(syscall.SYS_PPOLL, uintptr(unsafe.Pointer(&files[0])), uintptr(len(files)), uintptr(unsafe.Pointer(limit)), 0, 0, 0)
+	return int(n), errno
+}
+
+const sysRenameAt2 = syscall.SYS_RENAMEAT2
+
+const sysMemoryLock2 = 284 // SYS_MLOCK2
+
+const sysMemoryFileCreate = syscall.SYS_MEMFD_CREATE
+
+const (
+	sysClockGetTime       = syscall.SYS_CLOCK_GETTIME
+	sysClockGetResolution = syscall.SYS_CLOCK_GETRES
+	sysClockSleep         = syscall.SYS_CLOCK_NANOSLEEP
+)
+
+const sysFutex = syscall.SYS_FUTEX
+
+// seek with lseek(2).
+func seek(fd FileDescriptor, offset int64, whence Seek) (int64, syscall.Errno) {
+	o, _, errno := syscall.Syscall(syscall.SYS_LSEEK, uintptr(fd), uintptr(offset), uintptr(whence))
+	return int64(o), errno
+}
+
+// mmap with mmap(2), see [pointer].
+func mmap(addr unsafe.Pointer, length int, prot MemoryProtection, flags int, fd FileDescriptor, offset uintptr) (uintptr, syscall.Errno) {
+	ptr, _, errno := syscall.Syscall6(syscall.SYS_MMAP, uintptr(addr), uintptr(length), uintptr(prot), uintptr(flags), uintptr(fd), offset)
+	return ptr, errno
+}
@@ -0,0 +1,139 @@
+package linux_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"verbose.style/linux"
+)
+
+func TestClock(t *testing.T) {
+	for name, api := range map[string]*linux.API{"Native": linux.Native(), "Memory": linux.Memory()} {
+		t.Run(name, func(t *testing.T) {
+			var clocks = []linux.Clock{linux.ClockRealtime, linux.ClockMonotonic, linux.ClockBoottime}
+			if name == "Native" {
+				clocks = append(clocks, linux.ClockProcessTime, linux.ClockThreadTime, linux.ClockAtomicTime)
+			}
+			for _, clock := range clocks {
+				first, err := api.ClockTime(clock)
+				if err != nil {
+					t.Fatalf("%v: %v", clock, err)
+				}
+				second, err := api.ClockTime(clock)
+				if err != nil {
+					t.Fatalf("%v: %v", clock, err)
+				}
+				if second.AsDuration() < first.AsDuration() {
+					t.Fatalf("%v went backwards from %v to %v", clock, first, second)
+				}
+				resolution, err := api.ClockResolution(clock)
+				if err != nil {
+					t.Fatalf("%v: %v", clock, err)
+				}
+				if resolution.AsDuration() <= 0 || resolution.AsDuration() > 10*time.Millisecond {
+					t.Fatalf("%v has a resolution of %v", clock, resolution.AsDuration())
+				}
+			}
+			now, err := api.ClockTime(linux.ClockRealtime)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if drift := time.Since(now.AsTime()); drift < 0 || drift > time.Second {
+				t.Fatalf("realtime clock is %v behind time.Now", drift)
+			}
+			if _, err := api.ClockTime(-1); !errors.Is(err, new(linux.ClockError).Types().Invalid) {
+				t.Fatalf("expected invalid, got %v", err)
+			}
+		})
+	}
+}
+
+func TestMemoryClockUnsupported(t *testing.T) {
+	var api = linux.Memory()
+	for _, clock := range []linux.Clock{linux.ClockProcessTime, linux.ClockThreadTime, linux.ClockAtomicTime} {
+		if _, err := api.ClockTime(clock); !errors.Is(err, new(linux.ClockError).Types().Invalid) {
+			t.Fatalf("%v: expected invalid, got %v", clock, err)
+		}
+		if _, err := api.ClockResolution(clock); !errors.Is(err, new(linux.ClockError).Types().Invalid) {
+			t.Fatalf("%v: expected invalid, got %v", clock, err)
+		}
+	}
+	if _, err := api.Sleep(linux.ClockAtomicTime, 0, linux.TimeFromDuration(time.Millisecond)); !errors.Is(err, new(linux.SleepError).Types().Invalid) {
+		t.Fatalf("expected invalid, got %v", err)
+	}
+}
+
+func TestClockFileTimes(t *testing.T) {
+	var api = linux.Native()
+	var name = linux.Path(t.TempDir() + "/file")
+	before, err := api.ClockTime(linux.ClockRealtime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := api.Open(name, linux.FileAccessWriteOnly, linux.FileCreateIfNeeded, 0, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+	header, err := api.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	after, err := api.ClockTime(linux.ClockRealtime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// file times are taken from the coarse clock, which lags by up to a tick.
+	if header.ModifiedAt.AsTime().Before(before.AsTime().Add(-100*time.Millisecond)) || header.ModifiedAt.AsTime().After(after.AsTime()) {
+		t.Fatalf("file was modified at %v, outside of %v to %v", header.ModifiedAt.AsTime(), before.AsTime(), after.AsTime())
+	}
+}
+
+func TestSleep(t *testing.T) {
+	for name, api := range map[string]*linux.API{"Native": linux.Native(), "Memory": linux.Memory()} {
+		t.Run(name, func(t *testing.T) {
+			var start = time.Now()
+			if _, err := api.Sleep(linux.ClockMonotonic, 0, linux.TimeFromDuration(10*time.Millisecond)); err != nil {
+				t.Fatal(err)
+			}
+			if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+				t.Fatalf("relative sleep returned after %v", elapsed)
+			}
+
+			now, err := api.ClockTime(linux.ClockMonotonic)
+			if err != nil {
+				t.Fatal(err)
+			}
+			start = time.Now()
+			var deadline = linux.TimeFromDuration(now.AsDuration() + 10*time.Millisecond)
+			if _, err := api.Sleep(linux.ClockMonotonic, linux.SleepUntil, deadline); err != nil {
+				t.Fatal(err)
+			}
+			if elapsed := time.Since(start); elapsed < 5*time.Millisecond {
+				t.Fatalf("absolute sleep returned after %v", elapsed)
+			}
+			if _, err := api.Sleep(linux.ClockRealtime, linux.SleepUntil, linux.TimeFrom(time.Now().Add(-time.Hour))); err != nil {
+				t.Fatal(err)
+			}
+
+			var futex uint32
+			now, _ = api.ClockTime(linux.ClockMonotonic)
+			start = time.Now()
+			deadline = linux.TimeFromDuration(now.AsDuration() + 10*time.Millisecond)
+			if err := api.WaitFutexBitset(&futex, 0, &deadline, 1, 0); !errors.Is(err, new(linux.FutexError).Types().TimedOut) {
+				t.Fatalf("expected timed out, got %v", err)
+			}
+			if elapsed := time.Since(start); elapsed < 5*time.Millisecond {
+				t.Fatalf("futex deadline on the monotonic clock expired after %v", elapsed)
+			}
+
+			if _, err := api.Sleep(linux.ClockMonotonic, 0, linux.TimeFromDuration(-time.Second)); !errors.Is(err, new(linux.SleepError).Types().Invalid) {
+				t.Fatalf("expected invalid, got %v", err)
+			}
+			if _, err := api.Sleep(linux.ClockThreadTime, 0, linux.TimeFromDuration(time.Millisecond)); !errors.Is(err, new(linux.SleepError).Types().Unsupported) {
+				t.Fatalf("expected unsupported, got %v", err)
+			}
+		})
+	}
+}
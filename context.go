@@ -0,0 +1,192 @@
+package linux
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"time"
+)
+
+// ContextError is returned by the context variants of [API] calls, such as
+// [PollContext], when the context is done before the call completes. It
+// matches the cause of the context with [errors.Is], usually
+// [context.Canceled] or [context.DeadlineExceeded].
+type ContextError struct {
+	Operation string // name of the call, for example "Poll".
+	Err       error  // cause of the context, see [context.Cause].
+}
+
+func (e *ContextError) Error() string { return e.Operation + ": " + e.Err.Error() }
+
+func (e *ContextError) Unwrap() error { return e.Err }
+
+// PollContext is [API.Poll], that also returns a [ContextError] once ctx is
+// done. The wait is woken up by an event from [API.CreateEvent] that is
+// added to the files to poll and signalled when ctx is done. Interrupted
+// polls are retried with the remaining timeout.
+func PollContext(ctx context.Context, api *API, files []FileToPoll, timeout time.Duration) (int, error) {
+	if err := context.Cause(ctx); err != nil {
+		return 0, &ContextError{Operation: "Poll", Err: err}
+	}
+	waker, err := newContextWaker(ctx, api)
+	if err != nil {
+		return 0, err
+	}
+	defer waker.close()
+	return waker.poll(files, timeout)
+}
+
+// contextWaker holds the event that wakes up the polls of [PollContext] once
+// its context is done, so that a loop of polls creates only one event.
+type contextWaker struct {
+	ctx       context.Context
+	api       *API
+	event     FileDescriptor // -1 if the context is never done.
+	signalled chan struct{}
+	stop      func() bool
+	set       []FileToPoll
+}
+
+func newContextWaker(ctx context.Context, api *API) (*contextWaker, error) {
+	var waker = &contextWaker{ctx: ctx, api: api, event: -1}
+	if ctx.Done() == nil {
+		return waker, nil
+	}
+	event, err := api.CreateEvent(0, EventCloseOnExecute|EventNonBlocking)
+	if err != nil {
+		return nil, err
+	}
+	waker.event = event.Descriptor
+	waker.signalled = make(chan struct{})
+	waker.stop = context.AfterFunc(ctx, func() {
+		defer close(waker.signalled)
+		var one [8]byte
+		binary.NativeEndian.PutUint64(one[:], 1)
+		api.Write(waker.event, one[:])
+	})
+	return waker, nil
+}
+
+func (waker *contextWaker) close() {
+	if waker.stop == nil {
+		return
+	}
+	if !waker.stop() {
+		<-waker.signalled // the event must not be closed while it is being signalled.
+	}
+	waker.api.Close(waker.event)
+}
+
+// poll the files together with the event, see [PollContext].
+func (waker *contextWaker) poll(files []FileToPoll, timeout time.Duration) (int, error) {
+	if waker.stop == nil {
+		return waker.api.Poll(files, timeout)
+	}
+	var set = append(append(waker.set[:0], files...), FileToPoll{File: waker.event, Notify: PollHasReadAvailable})
+	waker.set = set
+	var deadline = time.Now().Add(timeout)
+	for {
+		n, err := waker.api.Poll(set, timeout)
+		if errors.Is(err, new(PollError).Types().Interrupted) && waker.ctx.Err() == nil {
+			if timeout < 0 {
+				continue
+			}
+			if timeout = time.Until(deadline); timeout > 0 {
+				continue
+			}
+			n, err = 0, nil
+		}
+		copy(files, set)
+		if set[len(files)].Result != 0 {
+			return 0, &ContextError{Operation: "Poll", Err: context.Cause(waker.ctx)}
+		}
+		return n, err
+	}
+}
+
+// wait until the file has any of the given notifications, see [File.WaitContext].
+func (waker *contextWaker) wait(f *File, notify Poll) (Poll, error) {
+	var files = []FileToPoll{{File: f.Descriptor, Notify: notify}}
+	for {
+		if err := context.Cause(waker.ctx); err != nil {
+			return 0, &ContextError{Operation: "Poll", Err: err}
+		}
+		if _, err := waker.poll(files, -1); err != nil {
+			return 0, err
+		}
+		if files[0].Result != 0 {
+			return files[0].Result, nil
+		}
+	}
+}
+
+// WaitContext waits until the file has any of the given notifications
+// available, for example [PollHasReadAvailable] before accepting a connection
+// on a listening socket, and returns the notifications that are, or a
+// [ContextError] once ctx is done.
+func (f *File) WaitContext(ctx context.Context, notify Poll) (Poll, error) {
+	waker, err := newContextWaker(ctx, f.Linux)
+	if err != nil {
+		return 0, err
+	}
+	defer waker.close()
+	return waker.wait(f, notify)
+}
+
+// ReadContext is [File.Read], that waits for the file to be ready for reading
+// with [File.WaitContext] so that the read does not block past ctx. Files
+// that are not opened with [FileNonBlocking] can still block past ctx, if
+// another reader empties the file between the wait and the read.
+func (f *File) ReadContext(ctx context.Context, p []byte) (int, error) {
+	waker, err := newContextWaker(ctx, f.Linux)
+	if err != nil {
+		return 0, err
+	}
+	defer waker.close()
+	for {
+		if _, err := waker.wait(f, PollHasReadAvailable); err != nil {
+			return 0, err
+		}
+		n, err := f.Linux.Read(f.Descriptor, p)
+		if err != nil && errors.Is(err, new(ReadError).Types().WouldBlock) {
+			continue
+		}
+		return int(n), err
+	}
+}
+
+// WriteContext is [File.Write], that waits for the file to be ready for
+// writing with [File.WaitContext] so that the write does not block past ctx.
+// Files that are not opened with [FileNonBlocking] can still block past ctx,
+// if the write is larger than the space available.
+func (f *File) WriteContext(ctx context.Context, p []byte) (int, error) {
+	waker, err := newContextWaker(ctx, f.Linux)
+	if err != nil {
+		return 0, err
+	}
+	defer waker.close()
+	for {
+		if _, err := waker.wait(f, PollHasWriteAvailable); err != nil {
+			return 0, err
+		}
+		n, err := f.Linux.Write(f.Descriptor, p)
+		if err != nil && errors.Is(err, new(WriteError).Types().WouldBlock) {
+			continue
+		}
+		return int(n), err
+	}
+}
+
+// ReadContext is [API.Read], see [File.ReadContext].
+func ReadContext(ctx context.Context, api *API, fd FileDescriptor, buf []byte) (Bytes, error) {
+	var file = File{Linux: api, Descriptor: fd}
+	n, err := file.ReadContext(ctx, buf)
+	return Bytes(n), err
+}
+
+// WriteContext is [API.Write], see [File.WriteContext].
+func WriteContext(ctx context.Context, api *API, fd FileDescriptor, buf []byte) (Bytes, error) {
+	var file = File{Linux: api, Descriptor: fd}
+	n, err := file.WriteContext(ctx, buf)
+	return Bytes(n), err
+}
@@ -0,0 +1,117 @@
+package linux_test
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	"verbose.style/linux"
+)
+
+func TestReadContext(t *testing.T) {
+	api := linux.Native()
+	event, err := api.CreateEvent(0, linux.EventCloseOnExecute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer event.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	var buf [8]byte
+	_, err = event.ReadContext(ctx, buf[:])
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected canceled, got %v", err)
+	}
+	var cerr *linux.ContextError
+	if !errors.As(err, &cerr) || cerr.Operation != "Poll" {
+		t.Fatalf("expected a context error, got %#v", err)
+	}
+	binary.NativeEndian.PutUint64(buf[:], 3)
+	if _, err := event.WriteContext(context.Background(), buf[:]); err != nil {
+		t.Fatal(err)
+	}
+	n, err := event.ReadContext(context.Background(), buf[:])
+	if err != nil || n != 8 || binary.NativeEndian.Uint64(buf[:]) != 3 {
+		t.Fatalf("read %d %v %v", n, buf, err)
+	}
+}
+
+func TestReadContextEvents(t *testing.T) {
+	var api = new(linux.API)
+	*api = *linux.Native()
+	var events, blocked int
+	var create = api.CreateEvent
+	api.CreateEvent = func(initial uint32, flags linux.EventFlags) (linux.File, error) {
+		events++
+		return create(initial, flags)
+	}
+	var read = api.Read
+	api.Read = func(fd linux.FileDescriptor, buf []byte) (linux.Bytes, error) {
+		if blocked < 3 {
+			blocked++
+			return 0, new(linux.ReadError).Types().WouldBlock
+		}
+		return read(fd, buf)
+	}
+	event, err := linux.Native().CreateEvent(1, linux.EventCloseOnExecute|linux.EventNonBlocking)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer event.Close()
+	event.Linux = api
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var buf [8]byte
+	if n, err := event.ReadContext(ctx, buf[:]); err != nil || n != 8 {
+		t.Fatalf("read %d %v", n, err)
+	}
+	if events != 1 {
+		t.Fatalf("expected one event for the wait loop, created %d", events)
+	}
+}
+
+func TestPollContext(t *testing.T) {
+	for name, api := range map[string]*linux.API{
+		"Native": linux.Native(),
+		"Memory": linux.Memory(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			event, err := api.CreateEvent(1, linux.EventNonBlocking|linux.EventSemaphore)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer event.Close()
+			var files = []linux.FileToPoll{{File: event.Descriptor, Notify: linux.PollHasReadAvailable}}
+			n, err := linux.PollContext(context.Background(), api, files, time.Second)
+			if err != nil || n != 1 || files[0].Result != linux.PollHasReadAvailable {
+				t.Fatalf("poll %d %v %v", n, files[0].Result, err)
+			}
+			var buf [8]byte
+			if n, err := event.Read(buf[:]); err != nil || n != 8 || binary.NativeEndian.Uint64(buf[:]) != 1 {
+				t.Fatalf("read %d %v %v", n, buf, err)
+			}
+			if _, err := api.Read(event.Descriptor, buf[:]); !errors.Is(err, new(linux.ReadError).Types().WouldBlock) {
+				t.Fatalf("expected would block, got %v", err)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			_, err = linux.PollContext(ctx, api, files, -1)
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("expected deadline exceeded, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCreateEventInvalid(t *testing.T) {
+	for name, api := range map[string]*linux.API{
+		"Native": linux.Native(),
+		"Memory": linux.Memory(),
+	} {
+		if _, err := api.CreateEvent(0, 0x2); !errors.Is(err, new(linux.EventError).Types().Invalid) {
+			t.Fatalf("%s: expected invalid, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package linux
+
+import (
+	"encoding/binary"
+	"unsafe"
+)
+
+// DirectoryEntry read by [File.ReadDirectory].
+type DirectoryEntry struct {
+	IndexNode IndexNode
+	Type      FileType // zero if the file system does not record the type in the directory.
+	Name      Path     // name of the entry within the directory.
+}
+
+// ReadDirectory reads the next entries of the directory into buf, and returns
+// them, no entries are returned at the end of the directory. The entries
+// include "." and "..", in the order that the file system lists them.
+func (f *File) ReadDirectory(buf []byte) ([]DirectoryEntry, error) {
+	n, err := f.Linux.ReadDirectory(f.Descriptor, buf)
+	if err != nil || n <= 0 {
+		return nil, err
+	}
+	return decodeDirectory(buf[:min(n, Bytes(len(buf)))]), nil
+}
+
+// directoryEntryHeader is the fixed size part of struct linux_dirent64, which
+// is followed by the null-terminated name and padded to 8 bytes.
+const directoryEntryHeader = int(unsafe.Offsetof(struct {
+	ino    uint64
+	off    int64
+	reclen uint16
+	typ    uint8
+	name   [1]byte
+}{}.name))
+
+func decodeDirectory(buf []byte) []DirectoryEntry {
+	var entries []DirectoryEntry
+	for len(buf) >= directoryEntryHeader {
+		var length = int(binary.NativeEndian.Uint16(buf[16:]))
+		if length < directoryEntryHeader || length > len(buf) {
+			break
+		}
+		var name = buf[directoryEntryHeader:length]
+		for i, c := range name {
+			if c == 0 {
+				name = name[:i]
+				break
+			}
+		}
+		entries = append(entries, DirectoryEntry{
+			IndexNode: IndexNode(binary.NativeEndian.Uint64(buf)),
+			Type:      FileType(buf[18]) << 12,
+			Name:      Path(name),
+		})
+		buf = buf[length:]
+	}
+	return entries
+}
+
+// encodeDirectory appends entry to buf in the format of getdents64(2), the
+// offset is that of the next entry.
+func encodeDirectory(buf []byte, entry DirectoryEntry, offset int64) []byte {
+	var length = encodedDirectorySize(entry)
+	buf = binary.NativeEndian.AppendUint64(buf, uint64(entry.IndexNode))
+	buf = binary.NativeEndian.AppendUint64(buf, uint64(offset))
+	buf = binary.NativeEndian.AppendUint16(buf, uint16(length))
+	buf = append(buf, byte(entry.Type>>12))
+	buf = append(buf, entry.Name...)
+	return append(buf, make([]byte, length-directoryEntryHeader-len(entry.Name))...)
+}
+
+// encodedDirectorySize of entry, in the format of getdents64(2).
+func encodedDirectorySize(entry DirectoryEntry) int {
+	return (directoryEntryHeader + len(entry.Name) + 1 + 7) &^ 7
+}
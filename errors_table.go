@@ -0,0 +1,820 @@
+// Code generated by "go run ./internal/errorsgen -input errors_types.go"; DO NOT EDIT.
+
+package linux
+
+import "syscall"
+
+func init() {
+	var readError ReadError
+	var readErrorTypes = zeroOf(readError.ErrMethods)
+	setErrno(&readErrorTypes.WouldBlock.ErrMethods, syscall.EAGAIN)
+	setErrno(&readErrorTypes.BadFile.ErrMethods, syscall.EBADF)
+	setErrno(&readErrorTypes.Fault.ErrMethods, syscall.EFAULT)
+	setErrno(&readErrorTypes.Interrupted.ErrMethods, syscall.EINTR)
+	setErrno(&readErrorTypes.Invalid.ErrMethods, syscall.EINVAL)
+	setErrno(&readErrorTypes.IO.ErrMethods, syscall.EIO)
+	setErrno(&readErrorTypes.Directory.ErrMethods, syscall.EISDIR)
+	setErrno(&readErrorTypes.Overflow.ErrMethods, syscall.EOVERFLOW)
+	errorTables[readError.ErrMethods] = &errorTable{
+		types: readErrorTypes,
+		names: []string{
+			syscall.EAGAIN:    "WouldBlock",
+			syscall.EBADF:     "BadFile",
+			syscall.EFAULT:    "Fault",
+			syscall.EINTR:     "Interrupted",
+			syscall.EINVAL:    "Invalid",
+			syscall.EIO:       "IO",
+			syscall.EISDIR:    "Directory",
+			syscall.EOVERFLOW: "Overflow",
+		},
+		parse: func(errno syscall.Errno) error {
+			var err ReadError
+			setErrno(&err.ErrMethods, errno)
+			return err
+		},
+	}
+	errorTypes["ReadError"] = errorTables[readError.ErrMethods]
+	var writeError WriteError
+	var writeErrorTypes = zeroOf(writeError.ErrMethods)
+	setErrno(&writeErrorTypes.WouldBlock.ErrMethods, syscall.EAGAIN)
+	setErrno(&writeErrorTypes.BadFile.ErrMethods, syscall.EBADF)
+	setErrno(&writeErrorTypes.NoDestination.ErrMethods, syscall.EDESTADDRREQ)
+	setErrno(&writeErrorTypes.QuotaExhausted.ErrMethods, syscall.EDQUOT)
+	setErrno(&writeErrorTypes.Fault.ErrMethods, syscall.EFAULT)
+	setErrno(&writeErrorTypes.TooMuch.ErrMethods, syscall.EFBIG)
+	setErrno(&writeErrorTypes.Interrupted.ErrMethods, syscall.EINTR)
+	setErrno(&writeErrorTypes.Invalid.ErrMethods, syscall.EINVAL)
+	setErrno(&writeErrorTypes.IO.ErrMethods, syscall.EIO)
+	setErrno(&writeErrorTypes.NoMoreSpace.ErrMethods, syscall.ENOSPC)
+	setErrno(&writeErrorTypes.NotPermitted.ErrMethods, syscall.EPERM)
+	setErrno(&writeErrorTypes.BrokenPipe.ErrMethods, syscall.EPIPE)
+	errorTables[writeError.ErrMethods] = &errorTable{
+		types: writeErrorTypes,
+		names: []string{
+			syscall.EAGAIN:       "WouldBlock",
+			syscall.EBADF:        "BadFile",
+			syscall.EDESTADDRREQ: "NoDestination",
+			syscall.EDQUOT:       "QuotaExhausted",
+			syscall.EFAULT:       "Fault",
+			syscall.EFBIG:        "TooMuch",
+			syscall.EINTR:        "Interrupted",
+			syscall.EINVAL:       "Invalid",
+			syscall.EIO:          "IO",
+			syscall.ENOSPC:       "NoMoreSpace",
+			syscall.EPERM:        "NotPermitted",
+			syscall.EPIPE:        "BrokenPipe",
+		},
+		parse: func(errno syscall.Errno) error {
+			var err WriteError
+			setErrno(&err.ErrMethods, errno)
+			return err
+		},
+	}
+	errorTypes["WriteError"] = errorTables[writeError.ErrMethods]
+	var openError OpenError
+	var openErrorTypes = zeroOf(openError.ErrMethods)
+	setErrno(&openErrorTypes.AccessDenied.ErrMethods, syscall.EACCES)
+	setErrno(&openErrorTypes.BadFile.ErrMethods, syscall.EBADF)
+	setErrno(&openErrorTypes.Busy.ErrMethods, syscall.EBUSY)
+	setErrno(&openErrorTypes.QuotaExhausted.ErrMethods, syscall.EDQUOT)
+	setErrno(&openErrorTypes.AlreadyExists.ErrMethods, syscall.EEXIST)
+	setErrno(&openErrorTypes.Fault.ErrMethods, syscall.EFAULT)
+	setErrno(&openErrorTypes.FileTooLarge.ErrMethods, syscall.EFBIG)
+	setErrno(&openErrorTypes.NotPermitted.ErrMethods, syscall.EPERM)
+	setErrno(&openErrorTypes.ReadOnly.ErrMethods, syscall.EROFS)
+	setErrno(&openErrorTypes.FileInUse.ErrMethods, syscall.ETXTBSY)
+	setErrno(&openErrorTypes.WouldBlock.ErrMethods, syscall.EAGAIN)
+	setErrno(&openErrorTypes.Interrupted.ErrMethods, syscall.EINTR)
+	setErrno(&openErrorTypes.Invalid.ErrMethods, syscall.EINVAL)
+	setErrno(&openErrorTypes.Directory.ErrMethods, syscall.EISDIR)
+	setErrno(&openErrorTypes.Loop.ErrMethods, syscall.ELOOP)
+	setErrno(&openErrorTypes.TooManyFiles.ErrMethods, syscall.EMFILE)
+	setErrno(&openErrorTypes.NameTooLong.ErrMethods, syscall.ENAMETOOLONG)
+	setErrno(&openErrorTypes.TooManyFilesInSystem.ErrMethods, syscall.ENFILE)
+	setErrno(&openErrorTypes.NoDevice.ErrMethods, syscall.ENODEV)
+	setErrno(&openErrorTypes.DoesNotExist.ErrMethods, syscall.ENOENT)
+	setErrno(&openErrorTypes.OutOfMemory.ErrMethods, syscall.ENOMEM)
+	setErrno(&openErrorTypes.NoMoreSpace.ErrMethods, syscall.ENOSPC)
+	setErrno(&openErrorTypes.NotDirectory.ErrMethods, syscall.ENOTDIR)
+	setErrno(&openErrorTypes.NoSuchDevice.ErrMethods, syscall.ENXIO)
+	setErrno(&openErrorTypes.Unsupported.ErrMethods, syscall.ENOTSUP)
+	setErrno(&openErrorTypes.Overflow.ErrMethods, syscall.EOVERFLOW)
+	errorTables[openError.ErrMethods] = &errorTable{
+		types: openErrorTypes,
+		names: []string{
+			syscall.EACCES:       "AccessDenied",
+			syscall.EBADF:        "BadFile",
+			syscall.EBUSY:        "Busy",
+			syscall.EDQUOT:       "QuotaExhausted",
+			syscall.EEXIST:       "AlreadyExists",
+			syscall.EFAULT:       "Fault",
+			syscall.EFBIG:        "FileTooLarge",
+			syscall.EPERM:        "NotPermitted",
+			syscall.EROFS:        "ReadOnly",
+			syscall.ETXTBSY:      "FileInUse",
+			syscall.EAGAIN:       "WouldBlock",
+			syscall.EINTR:        "Interrupted",
+			syscall.EINVAL:       "Invalid",
+			syscall.EISDIR:       "Directory",
+			syscall.ELOOP:        "Loop",
+			syscall.EMFILE:       "TooManyFiles",
+			syscall.ENAMETOOLONG: "NameTooLong",
+			syscall.ENFILE:       "TooManyFilesInSystem",
+			syscall.ENODEV:       "NoDevice",
+			syscall.ENOENT:       "DoesNotExist",
+			syscall.ENOMEM:       "OutOfMemory",
+			syscall.ENOSPC:       "NoMoreSpace",
+			syscall.ENOTDIR:      "NotDirectory",
+			syscall.ENXIO:        "NoSuchDevice",
+			syscall.ENOTSUP:      "Unsupported",
+			syscall.EOVERFLOW:    "Overflow",
+		},
+		parse: func(errno syscall.Errno) error {
+			var err OpenError
+			setErrno(&err.ErrMethods, errno)
+			return err
+		},
+	}
+	errorTypes["OpenError"] = errorTables[openError.ErrMethods]
+	var closeError CloseError
+	var closeErrorTypes = zeroOf(closeError.ErrMethods)
+	setErrno(&closeErrorTypes.BadFile.ErrMethods, syscall.EBADF)
+	setErrno(&closeErrorTypes.Interrupted.ErrMethods, syscall.EINTR)
+	setErrno(&closeErrorTypes.IO.ErrMethods, syscall.EIO)
+	setErrno(&closeErrorTypes.QuotaExhausted.ErrMethods, syscall.EDQUOT)
+	setErrno(&closeErrorTypes.NoMoreSpace.ErrMethods, syscall.ENOSPC)
+	errorTables[closeError.ErrMethods] = &errorTable{
+		types: closeErrorTypes,
+		names: []string{
+			syscall.EBADF:  "BadFile",
+			syscall.EINTR:  "Interrupted",
+			syscall.EIO:    "IO",
+			syscall.EDQUOT: "QuotaExhausted",
+			syscall.ENOSPC: "NoMoreSpace",
+		},
+		parse: func(errno syscall.Errno) error {
+			var err CloseError
+			setErrno(&err.ErrMethods, errno)
+			return err
+		},
+	}
+	errorTypes["CloseError"] = errorTables[closeError.ErrMethods]
+	var statError StatError
+	var statErrorTypes = zeroOf(statError.ErrMethods)
+	setErrno(&statErrorTypes.DoesNotExist.ErrMethods, syscall.ENOENT)
+	setErrno(&statErrorTypes.AccessDenied.ErrMethods, syscall.EACCES)
+	setErrno(&statErrorTypes.BadFile.ErrMethods, syscall.EBADF)
+	setErrno(&statErrorTypes.Fault.ErrMethods, syscall.EFAULT)
+	setErrno(&statErrorTypes.Invalid.ErrMethods, syscall.EINVAL)
+	setErrno(&statErrorTypes.Loop.ErrMethods, syscall.ELOOP)
+	setErrno(&statErrorTypes.NameTooLong.ErrMethods, syscall.ENAMETOOLONG)
+	setErrno(&statErrorTypes.OutOfMemory.ErrMethods, syscall.ENOMEM)
+	setErrno(&statErrorTypes.NotDirectory.ErrMethods, syscall.ENOTDIR)
+	setErrno(&statErrorTypes.StatFileTooLarge.ErrMethods, syscall.EOVERFLOW)
+	errorTables[statError.ErrMethods] = &errorTable{
+		types: statErrorTypes,
+		names: []string{
+			syscall.ENOENT:       "DoesNotExist",
+			syscall.EACCES:       "AccessDenied",
+			syscall.EBADF:        "BadFile",
+			syscall.EFAULT:       "Fault",
+			syscall.EINVAL:       "Invalid",
+			syscall.ELOOP:        "Loop",
+			syscall.ENAMETOOLONG: "NameTooLong",
+			syscall.ENOMEM:       "OutOfMemory",
+			syscall.ENOTDIR:      "NotDirectory",
+			syscall.EOVERFLOW:    "StatFileTooLarge",
+		},
+		parse: func(errno syscall.Errno) error {
+			var err StatError
+			setErrno(&err.ErrMethods, errno)
+			return err
+		},
+	}
+	errorTypes["StatError"] = errorTables[statError.ErrMethods]
+	var pollError PollError
+	var pollErrorTypes = zeroOf(pollError.ErrMethods)
+	setErrno(&pollErrorTypes.Fault.ErrMethods, syscall.EFAULT)
+	setErrno(&pollErrorTypes.Interrupted.ErrMethods, syscall.EINTR)
+	setErrno(&pollErrorTypes.Invalid.ErrMethods, syscall.EINVAL)
+	setErrno(&pollErrorTypes.OutOfMemory.ErrMethods, syscall.ENOMEM)
+	errorTables[pollError.ErrMethods] = &errorTable{
+		types: pollErrorTypes,
+		names: []string{
+			syscall.EFAULT: "Fault",
+			syscall.EINTR:  "Interrupted",
+			syscall.EINVAL: "Invalid",
+			syscall.ENOMEM: "OutOfMemory",
+		},
+		parse: func(errno syscall.Errno) error {
+			var err PollError
+			setErrno(&err.ErrMethods, errno)
+			return err
+		},
+	}
+	errorTypes["PollError"] = errorTables[pollError.ErrMethods]
+	var seekError SeekError
+	var seekErrorTypes = zeroOf(seekError.ErrMethods)
+	setErrno(&seekErrorTypes.BadFile.ErrMethods, syscall.EBADF)
+	setErrno(&seekErrorTypes.Invalid.ErrMethods, syscall.EINVAL)
+	setErrno(&seekErrorTypes.NotFound.ErrMethods, syscall.ENXIO)
+	setErrno(&seekErrorTypes.Overflow.ErrMethods, syscall.EOVERFLOW)
+	setErrno(&seekErrorTypes.Illegal.ErrMethods, syscall.ESPIPE)
+	errorTables[seekError.ErrMethods] = &errorTable{
+		types: seekErrorTypes,
+		names: []string{
+			syscall.EBADF:     "BadFile",
+			syscall.EINVAL:    "Invalid",
+			syscall.ENXIO:     "NotFound",
+			syscall.EOVERFLOW: "Overflow",
+			syscall.ESPIPE:    "Illegal",
+		},
+		parse: func(errno syscall.Errno) error {
+			var err SeekError
+			setErrno(&err.ErrMethods, errno)
+			return err
+		},
+	}
+	errorTypes["SeekError"] = errorTables[seekError.ErrMethods]
+	var mapError MapError
+	var mapErrorTypes = zeroOf(mapError.ErrMethods)
+	setErrno(&mapErrorTypes.AccessDenied.ErrMethods, syscall.EACCES)
+	setErrno(&mapErrorTypes.Locked.ErrMethods, syscall.EAGAIN)
+	setErrno(&mapErrorTypes.BadFile.ErrMethods, syscall.EBADF)
+	setErrno(&mapErrorTypes.AlreadyExists.ErrMethods, syscall.EEXIST)
+	setErrno(&mapErrorTypes.Invalid.ErrMethods, syscall.EINVAL)
+	setErrno(&mapErrorTypes.TooManyFiles.ErrMethods, syscall.EMFILE)
+	setErrno(&mapErrorTypes.Unsupported.ErrMethods, syscall.ENODEV)
+	setErrno(&mapErrorTypes.OutOfMemory.ErrMethods, syscall.ENOMEM)
+	setErrno(&mapErrorTypes.Overflow.ErrMethods, syscall.EOVERFLOW)
+	setErrno(&mapErrorTypes.NotPermitted.ErrMethods, syscall.EPERM)
+	setErrno(&mapErrorTypes.TooManyFilesInSystem.ErrMethods, syscall.ENFILE)
+	setErrno(&mapErrorTypes.FileInUse.ErrMethods, syscall.ETXTBSY)
+	errorTables[mapError.ErrMethods] = &errorTable{
+		types: mapErrorTypes,
+		names: []string{
+			syscall.EACCES:    "AccessDenied",
+			syscall.EAGAIN:    "Locked",
+			syscall.EBADF:     "BadFile",
+			syscall.EEXIST:    "AlreadyExists",
+			syscall.EINVAL:    "Invalid",
+			syscall.EMFILE:    "TooManyFiles",
+			syscall.ENODEV:    "Unsupported",
+			syscall.ENOMEM:    "OutOfMemory",
+			syscall.EOVERFLOW: "Overflow",
+			syscall.EPERM:     "NotPermitted",
+			syscall.ENFILE:    "TooManyFilesInSystem",
+			syscall.ETXTBSY:   "FileInUse",
+		},
+		parse: func(errno syscall.Errno) error {
+			var err MapError
+			setErrno(&err.ErrMethods, errno)
+			return err
+		},
+	}
+	errorTypes["MapError"] = errorTables[mapError.ErrMethods]
+	var protectMemoryError ProtectMemoryError
+	var protectMemoryErrorTypes = zeroOf(protectMemoryError.ErrMethods)
+	setErrno(&protectMemoryErrorTypes.AccessDenied.ErrMethods, syscall.EACCES)
+	setErrno(&protectMemoryErrorTypes.Invalid.ErrMethods, syscall.EINVAL)
+	setErrno(&protectMemoryErrorTypes.OutOfMemory.ErrMethods, syscall.ENOMEM)
+	errorTables[protectMemoryError.ErrMethods] = &errorTable{
+		types: protectMemoryErrorTypes,
+		names: []string{
+			syscall.EACCES: "AccessDenied",
+			syscall.EINVAL: "Invalid",
+			syscall.ENOMEM: "OutOfMemory",
+		},
+		parse: func(errno syscall.Errno) error {
+			var err ProtectMemoryError
+			setErrno(&err.ErrMethods, errno)
+			return err
+		},
+	}
+	errorTypes["ProtectMemoryError"] = errorTables[protectMemoryError.ErrMethods]
+	var heapError HeapError
+	var heapErrorTypes = zeroOf(heapError.ErrMethods)
+	setErrno(&heapErrorTypes.OutOfMemory.ErrMethods, syscall.ENOMEM)
+	errorTables[heapError.ErrMethods] = &errorTable{
+		types: heapErrorTypes,
+		names: []string{
+			syscall.ENOMEM: "OutOfMemory",
+		},
+		parse: func(errno syscall.Errno) error {
+			var err HeapError
+			setErrno(&err.ErrMethods, errno)
+			return err
+		},
+	}
+	errorTypes["HeapError"] = errorTables[heapError.ErrMethods]
+	var readDirectoryError ReadDirectoryError
+	var readDirectoryErrorTypes = zeroOf(readDirectoryError.ErrMethods)
+	setErrno(&readDirectoryErrorTypes.BadFile.ErrMethods, syscall.EBADF)
+	setErrno(&readDirectoryErrorTypes.Fault.ErrMethods, syscall.EFAULT)
+	setErrno(&readDirectoryErrorTypes.BufferTooSmall.ErrMethods, syscall.EINVAL)
+	setErrno(&readDirectoryErrorTypes.NotFound.ErrMethods, syscall.ENOENT)
+	setErrno(&readDirectoryErrorTypes.NotDirectory.ErrMethods, syscall.ENOTDIR)
+	errorTables[readDirectoryError.ErrMethods] = &errorTable{
+		types: readDirectoryErrorTypes,
+		names: []string{
+			syscall.EBADF:   "BadFile",
+			syscall.EFAULT:  "Fault",
+			syscall.EINVAL:  "BufferTooSmall",
+			syscall.ENOENT:  "NotFound",
+			syscall.ENOTDIR: "NotDirectory",
+		},
+		parse: func(errno syscall.Errno) error {
+			var err ReadDirectoryError
+			setErrno(&err.ErrMethods, errno)
+			return err
+		},
+	}
+	errorTypes["ReadDirectoryError"] = errorTables[readDirectoryError.ErrMethods]
+	var makeDirectoryError MakeDirectoryError
+	var makeDirectoryErrorTypes = zeroOf(makeDirectoryError.ErrMethods)
+	setErrno(&makeDirectoryErrorTypes.AccessDenied.ErrMethods, syscall.EACCES)
+	setErrno(&makeDirectoryErrorTypes.QuotaExhausted.ErrMethods, syscall.EDQUOT)
+	setErrno(&makeDirectoryErrorTypes.AlreadyExists.ErrMethods, syscall.EEXIST)
+	setErrno(&makeDirectoryErrorTypes.Fault.ErrMethods, syscall.EFAULT)
+	setErrno(&makeDirectoryErrorTypes.Invalid.ErrMethods, syscall.EINVAL)
+	setErrno(&makeDirectoryErrorTypes.Loop.ErrMethods, syscall.ELOOP)
+	setErrno(&makeDirectoryErrorTypes.TooManyLinks.ErrMethods, syscall.EMLINK)
+	setErrno(&makeDirectoryErrorTypes.NameTooLong.ErrMethods, syscall.ENAMETOOLONG)
+	setErrno(&makeDirectoryErrorTypes.DoesNotExist.ErrMethods, syscall.ENOENT)
+	setErrno(&makeDirectoryErrorTypes.OutOfMemory.ErrMethods, syscall.ENOMEM)
+	setErrno(&makeDirectoryErrorTypes.NoMoreSpace.ErrMethods, syscall.ENOSPC)
+	setErrno(&makeDirectoryErrorTypes.NotDirectory.ErrMethods, syscall.ENOTDIR)
+	setErrno(&makeDirectoryErrorTypes.NotPermitted.ErrMethods, syscall.EPERM)
+	setErrno(&makeDirectoryErrorTypes.ReadOnly.ErrMethods, syscall.EROFS)
+	errorTables[makeDirectoryError.ErrMethods] = &errorTable{
+		types: makeDirectoryErrorTypes,
+		names: []string{
+			syscall.EACCES:       "AccessDenied",
+			syscall.EDQUOT:       "QuotaExhausted",
+			syscall.EEXIST:       "AlreadyExists",
+			syscall.EFAULT:       "Fault",
+			syscall.EINVAL:       "Invalid",
+			syscall.ELOOP:        "Loop",
+			syscall.EMLINK:       "TooManyLinks",
+			syscall.ENAMETOOLONG: "NameTooLong",
+			syscall.ENOENT:       "DoesNotExist",
+			syscall.ENOMEM:       "OutOfMemory",
+			syscall.ENOSPC:       "NoMoreSpace",
+			syscall.ENOTDIR:      "NotDirectory",
+			syscall.EPERM:        "NotPermitted",
+			syscall.EROFS:        "ReadOnly",
+		},
+		parse: func(errno syscall.Errno) error {
+			var err MakeDirectoryError
+			setErrno(&err.ErrMethods, errno)
+			return err
+		},
+	}
+	errorTypes["MakeDirectoryError"] = errorTables[makeDirectoryError.ErrMethods]
+	var removeError RemoveError
+	var removeErrorTypes = zeroOf(removeError.ErrMethods)
+	setErrno(&removeErrorTypes.AccessDenied.ErrMethods, syscall.EACCES)
+	setErrno(&removeErrorTypes.Busy.ErrMethods, syscall.EBUSY)
+	setErrno(&removeErrorTypes.Fault.ErrMethods, syscall.EFAULT)
+	setErrno(&removeErrorTypes.IO.ErrMethods, syscall.EIO)
+	setErrno(&removeErrorTypes.Directory.ErrMethods, syscall.EISDIR)
+	setErrno(&removeErrorTypes.Invalid.ErrMethods, syscall.EINVAL)
+	setErrno(&removeErrorTypes.Loop.ErrMethods, syscall.ELOOP)
+	setErrno(&removeErrorTypes.NameTooLong.ErrMethods, syscall.ENAMETOOLONG)
+	setErrno(&removeErrorTypes.DoesNotExist.ErrMethods, syscall.ENOENT)
+	setErrno(&removeErrorTypes.OutOfMemory.ErrMethods, syscall.ENOMEM)
+	setErrno(&removeErrorTypes.NotDirectory.ErrMethods, syscall.ENOTDIR)
+	setErrno(&removeErrorTypes.NotEmpty.ErrMethods, syscall.ENOTEMPTY)
+	setErrno(&removeErrorTypes.AlreadyExists.ErrMethods, syscall.EEXIST)
+	setErrno(&removeErrorTypes.NotPermitted.ErrMethods, syscall.EPERM)
+	setErrno(&removeErrorTypes.ReadOnly.ErrMethods, syscall.EROFS)
+	errorTables[removeError.ErrMethods] = &errorTable{
+		types: removeErrorTypes,
+		names: []string{
+			syscall.EACCES:       "AccessDenied",
+			syscall.EBUSY:        "Busy",
+			syscall.EFAULT:       "Fault",
+			syscall.EIO:          "IO",
+			syscall.EISDIR:       "Directory",
+			syscall.EINVAL:       "Invalid",
+			syscall.ELOOP:        "Loop",
+			syscall.ENAMETOOLONG: "NameTooLong",
+			syscall.ENOENT:       "DoesNotExist",
+			syscall.ENOMEM:       "OutOfMemory",
+			syscall.ENOTDIR:      "NotDirectory",
+			syscall.ENOTEMPTY:    "NotEmpty",
+			syscall.EEXIST:       "AlreadyExists",
+			syscall.EPERM:        "NotPermitted",
+			syscall.EROFS:        "ReadOnly",
+		},
+		parse: func(errno syscall.Errno) error {
+			var err RemoveError
+			setErrno(&err.ErrMethods, errno)
+			return err
+		},
+	}
+	errorTypes["RemoveError"] = errorTables[removeError.ErrMethods]
+	var renameError RenameError
+	var renameErrorTypes = zeroOf(renameError.ErrMethods)
+	setErrno(&renameErrorTypes.AccessDenied.ErrMethods, syscall.EACCES)
+	setErrno(&renameErrorTypes.Busy.ErrMethods, syscall.EBUSY)
+	setErrno(&renameErrorTypes.QuotaExhausted.ErrMethods, syscall.EDQUOT)
+	setErrno(&renameErrorTypes.Fault.ErrMethods, syscall.EFAULT)
+	setErrno(&renameErrorTypes.Invalid.ErrMethods, syscall.EINVAL)
+	setErrno(&renameErrorTypes.Directory.ErrMethods, syscall.EISDIR)
+	setErrno(&renameErrorTypes.Loop.ErrMethods, syscall.ELOOP)
+	setErrno(&renameErrorTypes.TooManyLinks.ErrMethods, syscall.EMLINK)
+	setErrno(&renameErrorTypes.NameTooLong.ErrMethods, syscall.ENAMETOOLONG)
+	setErrno(&renameErrorTypes.DoesNotExist.ErrMethods, syscall.ENOENT)
+	setErrno(&renameErrorTypes.OutOfMemory.ErrMethods, syscall.ENOMEM)
+	setErrno(&renameErrorTypes.NoMoreSpace.ErrMethods, syscall.ENOSPC)
+	setErrno(&renameErrorTypes.NotDirectory.ErrMethods, syscall.ENOTDIR)
+	setErrno(&renameErrorTypes.NotEmpty.ErrMethods, syscall.ENOTEMPTY)
+	setErrno(&renameErrorTypes.AlreadyExists.ErrMethods, syscall.EEXIST)
+	setErrno(&renameErrorTypes.NotPermitted.ErrMethods, syscall.EPERM)
+	setErrno(&renameErrorTypes.ReadOnly.ErrMethods, syscall.EROFS)
+	setErrno(&renameErrorTypes.CrossDevice.ErrMethods, syscall.EXDEV)
+	errorTables[renameError.ErrMethods] = &errorTable{
+		types: renameErrorTypes,
+		names: []string{
+			syscall.EACCES:       "AccessDenied",
+			syscall.EBUSY:        "Busy",
+			syscall.EDQUOT:       "QuotaExhausted",
+			syscall.EFAULT:       "Fault",
+			syscall.EINVAL:       "Invalid",
+			syscall.EISDIR:       "Directory",
+			syscall.ELOOP:        "Loop",
+			syscall.EMLINK:       "TooManyLinks",
+			syscall.ENAMETOOLONG: "NameTooLong",
+			syscall.ENOENT:       "DoesNotExist",
+			syscall.ENOMEM:       "OutOfMemory",
+			syscall.ENOSPC:       "NoMoreSpace",
+			syscall.ENOTDIR:      "NotDirectory",
+			syscall.ENOTEMPTY:    "NotEmpty",
+			syscall.EEXIST:       "AlreadyExists",
+			syscall.EPERM:        "NotPermitted",
+			syscall.EROFS:        "ReadOnly",
+			syscall.EXDEV:        "CrossDevice",
+		},
+		parse: func(errno syscall.Errno) error {
+			var err RenameError
+			setErrno(&err.ErrMethods, errno)
+			return err
+		},
+	}
+	errorTypes["RenameError"] = errorTables[renameError.ErrMethods]
+	var linkError LinkError
+	var linkErrorTypes = zeroOf(linkError.ErrMethods)
+	setErrno(&linkErrorTypes.AccessDenied.ErrMethods, syscall.EACCES)
+	setErrno(&linkErrorTypes.BadFile.ErrMethods, syscall.EBADF)
+	setErrno(&linkErrorTypes.QuotaExhausted.ErrMethods, syscall.EDQUOT)
+	setErrno(&linkErrorTypes.AlreadyExists.ErrMethods, syscall.EEXIST)
+	setErrno(&linkErrorTypes.Fault.ErrMethods, syscall.EFAULT)
+	setErrno(&linkErrorTypes.Invalid.ErrMethods, syscall.EINVAL)
+	setErrno(&linkErrorTypes.IO.ErrMethods, syscall.EIO)
+	setErrno(&linkErrorTypes.Loop.ErrMethods, syscall.ELOOP)
+	setErrno(&linkErrorTypes.TooManyLinks.ErrMethods, syscall.EMLINK)
+	setErrno(&linkErrorTypes.NameTooLong.ErrMethods, syscall.ENAMETOOLONG)
+	setErrno(&linkErrorTypes.DoesNotExist.ErrMethods, syscall.ENOENT)
+	setErrno(&linkErrorTypes.OutOfMemory.ErrMethods, syscall.ENOMEM)
+	setErrno(&linkErrorTypes.NoMoreSpace.ErrMethods, syscall.ENOSPC)
+	setErrno(&linkErrorTypes.NotDirectory.ErrMethods, syscall.ENOTDIR)
+	setErrno(&linkErrorTypes.NotPermitted.ErrMethods, syscall.EPERM)
+	setErrno(&linkErrorTypes.ReadOnly.ErrMethods, syscall.EROFS)
+	setErrno(&linkErrorTypes.CrossDevice.ErrMethods, syscall.EXDEV)
+	errorTables[linkError.ErrMethods] = &errorTable{
+		types: linkErrorTypes,
+		names: []string{
+			syscall.EACCES:       "AccessDenied",
+			syscall.EBADF:        "BadFile",
+			syscall.EDQUOT:       "QuotaExhausted",
+			syscall.EEXIST:       "AlreadyExists",
+			syscall.EFAULT:       "Fault",
+			syscall.EINVAL:       "Invalid",
+			syscall.EIO:          "IO",
+			syscall.ELOOP:        "Loop",
+			syscall.EMLINK:       "TooManyLinks",
+			syscall.ENAMETOOLONG: "NameTooLong",
+			syscall.ENOENT:       "DoesNotExist",
+			syscall.ENOMEM:       "OutOfMemory",
+			syscall.ENOSPC:       "NoMoreSpace",
+			syscall.ENOTDIR:      "NotDirectory",
+			syscall.EPERM:        "NotPermitted",
+			syscall.EROFS:        "ReadOnly",
+			syscall.EXDEV:        "CrossDevice",
+		},
+		parse: func(errno syscall.Errno) error {
+			var err LinkError
+			setErrno(&err.ErrMethods, errno)
+			return err
+		},
+	}
+	errorTypes["LinkError"] = errorTables[linkError.ErrMethods]
+	var syncError SyncError
+	var syncErrorTypes = zeroOf(syncError.ErrMethods)
+	setErrno(&syncErrorTypes.BadFile.ErrMethods, syscall.EBADF)
+	setErrno(&syncErrorTypes.QuotaExhausted.ErrMethods, syscall.EDQUOT)
+	setErrno(&syncErrorTypes.Interrupted.ErrMethods, syscall.EINTR)
+	setErrno(&syncErrorTypes.Invalid.ErrMethods, syscall.EINVAL)
+	setErrno(&syncErrorTypes.IO.ErrMethods, syscall.EIO)
+	setErrno(&syncErrorTypes.NoMoreSpace.ErrMethods, syscall.ENOSPC)
+	setErrno(&syncErrorTypes.ReadOnly.ErrMethods, syscall.EROFS)
+	errorTables[syncError.ErrMethods] = &errorTable{
+		types: syncErrorTypes,
+		names: []string{
+			syscall.EBADF:  "BadFile",
+			syscall.EDQUOT: "QuotaExhausted",
+			syscall.EINTR:  "Interrupted",
+			syscall.EINVAL: "Invalid",
+			syscall.EIO:    "IO",
+			syscall.ENOSPC: "NoMoreSpace",
+			syscall.EROFS:  "ReadOnly",
+		},
+		parse: func(errno syscall.Errno) error {
+			var err SyncError
+			setErrno(&err.ErrMethods, errno)
+			return err
+		},
+	}
+	errorTypes["SyncError"] = errorTables[syncError.ErrMethods]
+	var eventError EventError
+	var eventErrorTypes = zeroOf(eventError.ErrMethods)
+	setErrno(&eventErrorTypes.Invalid.ErrMethods, syscall.EINVAL)
+	setErrno(&eventErrorTypes.TooManyFiles.ErrMethods, syscall.EMFILE)
+	setErrno(&eventErrorTypes.TooManyFilesInSystem.ErrMethods, syscall.ENFILE)
+	setErrno(&eventErrorTypes.NoDevice.ErrMethods, syscall.ENODEV)
+	setErrno(&eventErrorTypes.OutOfMemory.ErrMethods, syscall.ENOMEM)
+	errorTables[eventError.ErrMethods] = &errorTable{
+		types: eventErrorTypes,
+		names: []string{
+			syscall.EINVAL: "Invalid",
+			syscall.EMFILE: "TooManyFiles",
+			syscall.ENFILE: "TooManyFilesInSystem",
+			syscall.ENODEV: "NoDevice",
+			syscall.ENOMEM: "OutOfMemory",
+		},
+		parse: func(errno syscall.Errno) error {
+			var err EventError
+			setErrno(&err.ErrMethods, errno)
+			return err
+		},
+	}
+	errorTypes["EventError"] = errorTables[eventError.ErrMethods]
+	var remapError RemapError
+	var remapErrorTypes = zeroOf(remapError.ErrMethods)
+	setErrno(&remapErrorTypes.Locked.ErrMethods, syscall.EAGAIN)
+	setErrno(&remapErrorTypes.Fault.ErrMethods, syscall.EFAULT)
+	setErrno(&remapErrorTypes.Invalid.ErrMethods, syscall.EINVAL)
+	setErrno(&remapErrorTypes.OutOfMemory.ErrMethods, syscall.ENOMEM)
+	errorTables[remapError.ErrMethods] = &errorTable{
+		types: remapErrorTypes,
+		names: []string{
+			syscall.EAGAIN: "Locked",
+			syscall.EFAULT: "Fault",
+			syscall.EINVAL: "Invalid",
+			syscall.ENOMEM: "OutOfMemory",
+		},
+		parse: func(errno syscall.Errno) error {
+			var err RemapError
+			setErrno(&err.ErrMethods, errno)
+			return err
+		},
+	}
+	errorTypes["RemapError"] = errorTables[remapError.ErrMethods]
+	var adviseMemoryError AdviseMemoryError
+	var adviseMemoryErrorTypes = zeroOf(adviseMemoryError.ErrMethods)
+	setErrno(&adviseMemoryErrorTypes.AccessDenied.ErrMethods, syscall.EACCES)
+	setErrno(&adviseMemoryErrorTypes.TryAgain.ErrMethods, syscall.EAGAIN)
+	setErrno(&adviseMemoryErrorTypes.BadFile.ErrMethods, syscall.EBADF)
+	setErrno(&adviseMemoryErrorTypes.Busy.ErrMethods, syscall.EBUSY)
+	setErrno(&adviseMemoryErrorTypes.Fault.ErrMethods, syscall.EFAULT)
+	setErrno(&adviseMemoryErrorTypes.Invalid.ErrMethods, syscall.EINVAL)
+	setErrno(&adviseMemoryErrorTypes.IO.ErrMethods, syscall.EIO)
+	setErrno(&adviseMemoryErrorTypes.OutOfMemory.ErrMethods, syscall.ENOMEM)
+	setErrno(&adviseMemoryErrorTypes.NotPermitted.ErrMethods, syscall.EPERM)
+	errorTables[adviseMemoryError.ErrMethods] = &errorTable{
+		types: adviseMemoryErrorTypes,
+		names: []string{
+			syscall.EACCES: "AccessDenied",
+			syscall.EAGAIN: "TryAgain",
+			syscall.EBADF:  "BadFile",
+			syscall.EBUSY:  "Busy",
+			syscall.EFAULT: "Fault",
+			syscall.EINVAL: "Invalid",
+			syscall.EIO:    "IO",
+			syscall.ENOMEM: "OutOfMemory",
+			syscall.EPERM:  "NotPermitted",
+		},
+		parse: func(errno syscall.Errno) error {
+			var err AdviseMemoryError
+			setErrno(&err.ErrMethods, errno)
+			return err
+		},
+	}
+	errorTypes["AdviseMemoryError"] = errorTables[adviseMemoryError.ErrMethods]
+	var lockMemoryError LockMemoryError
+	var lockMemoryErrorTypes = zeroOf(lockMemoryError.ErrMethods)
+	setErrno(&lockMemoryErrorTypes.TryAgain.ErrMethods, syscall.EAGAIN)
+	setErrno(&lockMemoryErrorTypes.Invalid.ErrMethods, syscall.EINVAL)
+	setErrno(&lockMemoryErrorTypes.OutOfMemory.ErrMethods, syscall.ENOMEM)
+	setErrno(&lockMemoryErrorTypes.NotPermitted.ErrMethods, syscall.EPERM)
+	errorTables[lockMemoryError.ErrMethods] = &errorTable{
+		types: lockMemoryErrorTypes,
+		names: []string{
+			syscall.EAGAIN: "TryAgain",
+			syscall.EINVAL: "Invalid",
+			syscall.ENOMEM: "OutOfMemory",
+			syscall.EPERM:  "NotPermitted",
+		},
+		parse: func(errno syscall.Errno) error {
+			var err LockMemoryError
+			setErrno(&err.ErrMethods, errno)
+			return err
+		},
+	}
+	errorTypes["LockMemoryError"] = errorTables[lockMemoryError.ErrMethods]
+	var memoryResidencyError MemoryResidencyError
+	var memoryResidencyErrorTypes = zeroOf(memoryResidencyError.ErrMethods)
+	setErrno(&memoryResidencyErrorTypes.TryAgain.ErrMethods, syscall.EAGAIN)
+	setErrno(&memoryResidencyErrorTypes.Fault.ErrMethods, syscall.EFAULT)
+	setErrno(&memoryResidencyErrorTypes.Invalid.ErrMethods, syscall.EINVAL)
+	setErrno(&memoryResidencyErrorTypes.OutOfMemory.ErrMethods, syscall.ENOMEM)
+	errorTables[memoryResidencyError.ErrMethods] = &errorTable{
+		types: memoryResidencyErrorTypes,
+		names: []string{
+			syscall.EAGAIN: "TryAgain",
+			syscall.EFAULT: "Fault",
+			syscall.EINVAL: "Invalid",
+			syscall.ENOMEM: "OutOfMemory",
+		},
+		parse: func(errno syscall.Errno) error {
+			var err MemoryResidencyError
+			setErrno(&err.ErrMethods, errno)
+			return err
+		},
+	}
+	errorTypes["MemoryResidencyError"] = errorTables[memoryResidencyError.ErrMethods]
+	var syncMemoryError SyncMemoryError
+	var syncMemoryErrorTypes = zeroOf(syncMemoryError.ErrMethods)
+	setErrno(&syncMemoryErrorTypes.Busy.ErrMethods, syscall.EBUSY)
+	setErrno(&syncMemoryErrorTypes.Invalid.ErrMethods, syscall.EINVAL)
+	setErrno(&syncMemoryErrorTypes.OutOfMemory.ErrMethods, syscall.ENOMEM)
+	errorTables[syncMemoryError.ErrMethods] = &errorTable{
+		types: syncMemoryErrorTypes,
+		names: []string{
+			syscall.EBUSY:  "Busy",
+			syscall.EINVAL: "Invalid",
+			syscall.ENOMEM: "OutOfMemory",
+		},
+		parse: func(errno syscall.Errno) error {
+			var err SyncMemoryError
+			setErrno(&err.ErrMethods, errno)
+			return err
+		},
+	}
+	errorTypes["SyncMemoryError"] = errorTables[syncMemoryError.ErrMethods]
+	var memoryFileError MemoryFileError
+	var memoryFileErrorTypes = zeroOf(memoryFileError.ErrMethods)
+	setErrno(&memoryFileErrorTypes.Fault.ErrMethods, syscall.EFAULT)
+	setErrno(&memoryFileErrorTypes.Invalid.ErrMethods, syscall.EINVAL)
+	setErrno(&memoryFileErrorTypes.TooManyFiles.ErrMethods, syscall.EMFILE)
+	setErrno(&memoryFileErrorTypes.TooManyFilesInSystem.ErrMethods, syscall.ENFILE)
+	setErrno(&memoryFileErrorTypes.OutOfMemory.ErrMethods, syscall.ENOMEM)
+	setErrno(&memoryFileErrorTypes.NotPermitted.ErrMethods, syscall.EPERM)
+	errorTables[memoryFileError.ErrMethods] = &errorTable{
+		types: memoryFileErrorTypes,
+		names: []string{
+			syscall.EFAULT: "Fault",
+			syscall.EINVAL: "Invalid",
+			syscall.EMFILE: "TooManyFiles",
+			syscall.ENFILE: "TooManyFilesInSystem",
+			syscall.ENOMEM: "OutOfMemory",
+			syscall.EPERM:  "NotPermitted",
+		},
+		parse: func(errno syscall.Errno) error {
+			var err MemoryFileError
+			setErrno(&err.ErrMethods, errno)
+			return err
+		},
+	}
+	errorTypes["MemoryFileError"] = errorTables[memoryFileError.ErrMethods]
+	var truncateError TruncateError
+	var truncateErrorTypes = zeroOf(truncateError.ErrMethods)
+	setErrno(&truncateErrorTypes.BadFile.ErrMethods, syscall.EBADF)
+	setErrno(&truncateErrorTypes.TooLarge.ErrMethods, syscall.EFBIG)
+	setErrno(&truncateErrorTypes.Interrupted.ErrMethods, syscall.EINTR)
+	setErrno(&truncateErrorTypes.Invalid.ErrMethods, syscall.EINVAL)
+	setErrno(&truncateErrorTypes.IO.ErrMethods, syscall.EIO)
+	setErrno(&truncateErrorTypes.NotPermitted.ErrMethods, syscall.EPERM)
+	setErrno(&truncateErrorTypes.ReadOnly.ErrMethods, syscall.EROFS)
+	setErrno(&truncateErrorTypes.FileInUse.ErrMethods, syscall.ETXTBSY)
+	errorTables[truncateError.ErrMethods] = &errorTable{
+		types: truncateErrorTypes,
+		names: []string{
+			syscall.EBADF:   "BadFile",
+			syscall.EFBIG:   "TooLarge",
+			syscall.EINTR:   "Interrupted",
+			syscall.EINVAL:  "Invalid",
+			syscall.EIO:     "IO",
+			syscall.EPERM:   "NotPermitted",
+			syscall.EROFS:   "ReadOnly",
+			syscall.ETXTBSY: "FileInUse",
+		},
+		parse: func(errno syscall.Errno) error {
+			var err TruncateError
+			setErrno(&err.ErrMethods, errno)
+			return err
+		},
+	}
+	errorTypes["TruncateError"] = errorTables[truncateError.ErrMethods]
+	var futexError FutexError
+	var futexErrorTypes = zeroOf(futexError.ErrMethods)
+	setErrno(&futexErrorTypes.AccessDenied.ErrMethods, syscall.EACCES)
+	setErrno(&futexErrorTypes.WouldBlock.ErrMethods, syscall.EAGAIN)
+	setErrno(&futexErrorTypes.Fault.ErrMethods, syscall.EFAULT)
+	setErrno(&futexErrorTypes.Interrupted.ErrMethods, syscall.EINTR)
+	setErrno(&futexErrorTypes.Invalid.ErrMethods, syscall.EINVAL)
+	setErrno(&futexErrorTypes.OutOfMemory.ErrMethods, syscall.ENOMEM)
+	setErrno(&futexErrorTypes.NotImplemented.ErrMethods, syscall.ENOSYS)
+	setErrno(&futexErrorTypes.TimedOut.ErrMethods, syscall.ETIMEDOUT)
+	errorTables[futexError.ErrMethods] = &errorTable{
+		types: futexErrorTypes,
+		names: []string{
+			syscall.EACCES:    "AccessDenied",
+			syscall.EAGAIN:    "WouldBlock",
+			syscall.EFAULT:    "Fault",
+			syscall.EINTR:     "Interrupted",
+			syscall.EINVAL:    "Invalid",
+			syscall.ENOMEM:    "OutOfMemory",
+			syscall.ENOSYS:    "NotImplemented",
+			syscall.ETIMEDOUT: "TimedOut",
+		},
+		parse: func(errno syscall.Errno) error {
+			var err FutexError
+			setErrno(&err.ErrMethods, errno)
+			return err
+		},
+	}
+	errorTypes["FutexError"] = errorTables[futexError.ErrMethods]
+	var mutexError MutexError
+	var mutexErrorTypes = zeroOf(mutexError.ErrMethods)
+	setErrno(&mutexErrorTypes.Invalid.ErrMethods, syscall.EINVAL)
+	setErrno(&mutexErrorTypes.NotPermitted.ErrMethods, syscall.EPERM)
+	setErrno(&mutexErrorTypes.OwnerDied.ErrMethods, syscall.EOWNERDEAD)
+	setErrno(&mutexErrorTypes.NotRecoverable.ErrMethods, syscall.ENOTRECOVERABLE)
+	setErrno(&mutexErrorTypes.NotImplemented.ErrMethods, syscall.ENOSYS)
+	errorTables[mutexError.ErrMethods] = &errorTable{
+		types: mutexErrorTypes,
+		names: []string{
+			syscall.EINVAL:          "Invalid",
+			syscall.EPERM:           "NotPermitted",
+			syscall.EOWNERDEAD:      "OwnerDied",
+			syscall.ENOTRECOVERABLE: "NotRecoverable",
+			syscall.ENOSYS:          "NotImplemented",
+		},
+		parse: func(errno syscall.Errno) error {
+			var err MutexError
+			setErrno(&err.ErrMethods, errno)
+			return err
+		},
+	}
+	errorTypes["MutexError"] = errorTables[mutexError.ErrMethods]
+	var clockError ClockError
+	var clockErrorTypes = zeroOf(clockError.ErrMethods)
+	setErrno(&clockErrorTypes.Fault.ErrMethods, syscall.EFAULT)
+	setErrno(&clockErrorTypes.Invalid.ErrMethods, syscall.EINVAL)
+	errorTables[clockError.ErrMethods] = &errorTable{
+		types: clockErrorTypes,
+		names: []string{
+			syscall.EFAULT: "Fault",
+			syscall.EINVAL: "Invalid",
+		},
+		parse: func(errno syscall.Errno) error {
+			var err ClockError
+			setErrno(&err.ErrMethods, errno)
+			return err
+		},
+	}
+	errorTypes["ClockError"] = errorTables[clockError.ErrMethods]
+	var sleepError SleepError
+	var sleepErrorTypes = zeroOf(sleepError.ErrMethods)
+	setErrno(&sleepErrorTypes.Fault.ErrMethods, syscall.EFAULT)
+	setErrno(&sleepErrorTypes.Interrupted.ErrMethods, syscall.EINTR)
+	setErrno(&sleepErrorTypes.Invalid.ErrMethods, syscall.EINVAL)
+	setErrno(&sleepErrorTypes.Unsupported.ErrMethods, syscall.ENOTSUP)
+	errorTables[sleepError.ErrMethods] = &errorTable{
+		types: sleepErrorTypes,
+		names: []string{
+			syscall.EFAULT:  "Fault",
+			syscall.EINTR:   "Interrupted",
+			syscall.EINVAL:  "Invalid",
+			syscall.ENOTSUP: "Unsupported",
+		},
+		parse: func(errno syscall.Errno) error {
+			var err SleepError
+			setErrno(&err.ErrMethods, errno)
+			return err
+		},
+	}
+	errorTypes["SleepError"] = errorTables[sleepError.ErrMethods]
+}
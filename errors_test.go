@@ -0,0 +1,106 @@
+package linux_test
+
+import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"reflect"
+	"syscall"
+	"testing"
+
+	"verbose.style/linux"
+)
+
+func TestErrors(t *testing.T) {
+	var Linux = linux.Native()
+
+	_, err := Linux.Stat("./does-not-exist")
+	if err != new(linux.StatError).Types().DoesNotExist {
+		t.Fatal(err)
+	}
+	if !errors.Is(err, fs.ErrNotExist) || !errors.Is(err, syscall.ENOENT) || errors.Is(err, fs.ErrExist) {
+		t.Fatal("StatError.DoesNotExist does not match its errno")
+	}
+	var statError linux.StatError
+	if !errors.As(err, &statError) || statError.Errno() != syscall.ENOENT {
+		t.Fatal(statError)
+	}
+
+	_, err = Linux.Open("./does-not-exist", linux.FileAccessReadOnly, 0, 0, 0)
+	var openError linux.OpenError
+	if !errors.As(err, &openError) || openError.Errno() != syscall.ENOENT || !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("unknown errno was not kept as an OpenError: %#v", err)
+	}
+
+	err = new(linux.OpenError).Types().AccessDenied
+	if !errors.Is(err, fs.ErrPermission) || err.Error() != "permission denied" {
+		t.Fatal(err)
+	}
+	if !errors.Is(new(linux.OpenError).Types().AlreadyExists, fs.ErrExist) {
+		t.Fatal("OpenError.AlreadyExists does not match fs.ErrExist")
+	}
+}
+
+// TestErrorTables checks that the generated tables are up to date with the
+// struct tags of every error type declared in errors_types.go, run go generate
+// when it fails.
+func TestErrorTables(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "errors_types.go", nil, parser.SkipObjectResolution)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tables = linux.ErrorTypes()
+	var declared int
+	for _, decl := range file.Decls {
+		decl, ok := decl.(*ast.GenDecl)
+		if !ok || decl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range decl.Specs {
+			var name = spec.(*ast.TypeSpec).Name.Name
+			types, ok := tables[name]
+			if !ok {
+				t.Errorf("%s has no table", name)
+				continue
+			}
+			declared++
+			var value = reflect.ValueOf(types)
+			for i := range value.NumField() {
+				var field = value.Type().Field(i)
+				if err := value.Field(i).Interface().(error); err.Error() != string(field.Tag) {
+					t.Errorf("%s.%s is %q, expected %q", name, field.Name, err, field.Tag)
+				}
+			}
+		}
+	}
+	if declared != len(tables) {
+		t.Errorf("%d error types are declared, but %d have tables", declared, len(tables))
+	}
+}
+
+func TestErrorAllocations(t *testing.T) {
+	var Linux = linux.Native()
+	var buf [1]byte
+	if allocs := testing.AllocsPerRun(100, func() { Linux.Read(-1, buf[:]) }); allocs != 0 {
+		t.Fatal(allocs, "allocations per mapped error")
+	}
+}
+
+func BenchmarkErrorWouldBlock(b *testing.B) {
+	var pipe [2]int
+	if err := syscall.Pipe2(pipe[:], syscall.O_NONBLOCK); err != nil {
+		b.Fatal(err)
+	}
+	defer syscall.Close(pipe[0])
+	defer syscall.Close(pipe[1])
+	var Linux = linux.Native()
+	var buf [1]byte
+	b.ReportAllocs()
+	for range b.N {
+		if _, err := Linux.Read(linux.FileDescriptor(pipe[0]), buf[:]); err != new(linux.ReadError).Types().WouldBlock {
+			b.Fatal(err)
+		}
+	}
+}
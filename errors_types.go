@@ -0,0 +1,339 @@
+// Code generated by "go run ./internal/errorsgen -catalogue internal/errorsgen/errnos.json"; DO NOT EDIT.
+
+package linux
+
+// ReadError returned by [API.Read], [File.Read] operations.
+type ReadError Error[struct {
+	WouldBlock  ReadError `resource temporarily unavailable`      // file requested as non-blocking and the read would block, try again later.
+	BadFile     ReadError `bad file descriptor`                   // file is not valid.
+	Fault       ReadError `bad address`                           // buffer is outside the accessible address space.
+	Interrupted ReadError `interrupted system call`               // read was interrupted by a signal.
+	Invalid     ReadError `invalid argument`                      // file is not suitable for reading.
+	IO          ReadError `input/output error`                    // an I/O error occurred.
+	Directory   ReadError `is a directory`                        // directories cannot be read.
+	Overflow    ReadError `value too large for defined data type` // file position would overflow, or the file is too large to be read on this system.
+}]
+
+// WriteError returned by [API.Write], [File.Write] operations.
+type WriteError Error[struct {
+	WouldBlock     WriteError `resource temporarily unavailable` // file requested as non-blocking and the write would block, try again later.
+	BadFile        WriteError `bad file descriptor`              // file is not valid.
+	NoDestination  WriteError `destination address required`     // files is a datagram socket and requires a destination address.
+	QuotaExhausted WriteError `disk quota exceeded`              // user's quota of space has run out.
+	Fault          WriteError `bad address`                      // buffer is outside the accessible address space.
+	TooMuch        WriteError `file too large`                   // file exceeds the maximum file size.
+	Interrupted    WriteError `interrupted system call`          // write was interrupted by a signal.
+	Invalid        WriteError `invalid argument`                 // file is not suitable for writing.
+	IO             WriteError `input/output error`               // an I/O error occurred.
+	NoMoreSpace    WriteError `no space left on device`          // device has no more space.
+	NotPermitted   WriteError `operation not permitted`          // file is not open for writing.
+	BrokenPipe     WriteError `broken pipe`                      // write to a closed pipe with no readers.
+}]
+
+// OpenError returned by [API.Open] operations.
+type OpenError Error[struct {
+	AccessDenied         OpenError `permission denied`                     // one of the directories is missing the search/execute permission bit, or wrong user.
+	BadFile              OpenError `bad file descriptor`                   // file is not valid.
+	Busy                 OpenError `device or resource busy`               // file is mounted and cannot be opened.
+	QuotaExhausted       OpenError `disk quota exceeded`                   // user's quota of space has run out.
+	AlreadyExists        OpenError `file exists`                           // file already exists and [FileCreateIfNeeded] and [FileAssertCreation] were used.
+	Fault                OpenError `bad address`                           // pathname is outside your accessible address space.
+	FileTooLarge         OpenError `file too large`                        // file exceeds architecture file size limit.
+	NotPermitted         OpenError `operation not permitted`               // permissions missing.
+	ReadOnly             OpenError `read-only file system`                 // file is on a read-only filesystem and write access was requested.
+	FileInUse            OpenError `text file busy`                        // file is an executable that is being executed and write access was requested.
+	WouldBlock           OpenError `resource temporarily unavailable`      // file requested as non-blocking and the open would block, try again later.
+	Interrupted          OpenError `interrupted system call`               // open was interrupted by a signal while blocked on a slow device.
+	Invalid              OpenError `invalid argument`                      // invalid flags, or [FileTemporaryInside] is used without write access.
+	Directory            OpenError `is a directory`                        // path is a directory and write access was requested.
+	Loop                 OpenError `too many levels of symbolic links`     // too many symbolic links, or [FileTrapSymbolicLink] was used on a symbolic link.
+	TooManyFiles         OpenError `too many open files`                   // process has too many files open.
+	NameTooLong          OpenError `file name too long`                    // path is too long.
+	TooManyFilesInSystem OpenError `too many open files in system`         // system has too many files open.
+	NoDevice             OpenError `no such device`                        // path refers to a device special file with no corresponding device.
+	DoesNotExist         OpenError `no such file or directory`             // an element in the path does not exist and [FileCreateIfNeeded] was not used.
+	OutOfMemory          OpenError `cannot allocate memory`                // kernel is out of memory, or a FIFO has reached its pipe buffer limit.
+	NoMoreSpace          OpenError `no space left on device`               // device has no more space to create the file.
+	NotDirectory         OpenError `not a directory`                       // a component of the path prefix is not a directory, or [FileAssertDirectory] was used on a file.
+	NoSuchDevice         OpenError `no such device or address`             // path is a FIFO with no reader and [FileNonBlocking] was used with write access, or a device that does not exist.
+	Unsupported          OpenError `operation not supported`               // file system does not support [FileTemporaryInside].
+	Overflow             OpenError `value too large for defined data type` // file is too large to be opened on this system.
+}]
+
+// CloseError returned by [API.Close] operations.
+type CloseError Error[struct {
+	BadFile        CloseError `bad file descriptor`     // file is not valid.
+	Interrupted    CloseError `interrupted system call` // close was interrupted by a signal.
+	IO             CloseError `input/output error`      // an I/O error occurred.
+	QuotaExhausted CloseError `disk quota exceeded`     // user's quota of space has run out, can be returned on close when IO is being buffered.
+	NoMoreSpace    CloseError `no space left on device` // device has no more space, can be returned on close when IO is being buffered.
+}]
+
+// StatError returned by [API.Stat], [API.StatLink] and [API.StatFile] operations.
+type StatError Error[struct {
+	DoesNotExist     StatError `no such file or directory`             // an element in the path does not exist.
+	AccessDenied     StatError `permission denied`                     // one of the directories is missing the search/execute permission bit.
+	BadFile          StatError `bad file descriptor`                   // file is not valid.
+	Fault            StatError `bad address`                           // path string is corrupted.
+	Invalid          StatError `invalid argument`                      // invalid flags
+	Loop             StatError `too many levels of symbolic links`     // recursion limit reached.
+	NameTooLong      StatError `file name too long`                    // unsupported file name
+	OutOfMemory      StatError `cannot allocate memory`                // kernel is out of memory
+	NotDirectory     StatError `not a directory`                       // a component of the path prefix is not a directory.
+	StatFileTooLarge StatError `value too large for defined data type` // file size is 64 bits and the system is 32 bits.
+}]
+
+// PollError returned by [API.Poll] operations.
+type PollError Error[struct {
+	Fault       PollError `bad address`             // files to poll is nil or points out of the accessible address space.
+	Interrupted PollError `interrupted system call` // poll was interrupted by a signal.
+	Invalid     PollError `invalid argument`        // too many files to poll or the timeout is invalid.
+	OutOfMemory PollError `cannot allocate memory`  // kernel is out of memory
+}]
+
+// SeekError returned by [API.Seek] operations.
+type SeekError Error[struct {
+	BadFile  SeekError `bad file descriptor`                   // file is not valid.
+	Invalid  SeekError `invalid argument`                      // invalid whence or resulting offset out of bounds.
+	NotFound SeekError `no such device or address`             // [SeekData] or [SeekHole] could not find a suitable offset within bounds.
+	Overflow SeekError `value too large for defined data type` // resulting offset is too large to fit in an int64.
+	Illegal  SeekError `illegal seek`                          // pipes/sockets are not seekable.
+}]
+
+// MapError returned by [API.MapFileIntoMemory] operations.
+type MapError Error[struct {
+	AccessDenied         MapError `permission denied`                     // non-regular file or [FileAccessMode] is incompatible with [MemoryProtection]
+	Locked               MapError `resource temporarily unavailable`      // file is locked, or too much locked memory in-use.
+	BadFile              MapError `bad file descriptor`                   // file is not valid and [MapAnonymous] not set.
+	AlreadyExists        MapError `file exists`                           // [MapExactAddressOnce] is set and the address is already mapped.
+	Invalid              MapError `invalid argument`                      // addr, length or offset is invalid and/or [MapType] missing.
+	TooManyFiles         MapError `too many open files`                   // process has too many files open.
+	Unsupported          MapError `no such device`                        // file system does not support mapping.
+	OutOfMemory          MapError `cannot allocate memory`                // kernel is out of memory, and/or addr exceeds the virtual address space.
+	Overflow             MapError `value too large for defined data type` // resulting offset is too large to fit in an int64.
+	NotPermitted         MapError `operation not permitted`               // file is not readable or writable, and/or process huge page capabilities.
+	TooManyFilesInSystem MapError `too many open files in system`         // system has too many files open.
+	FileInUse            MapError `text file busy`                        // file is an executable that is being executed and write access was requested.
+}]
+
+// ProtectMemoryError returned by [API.ProtectMemory] operations.
+type ProtectMemoryError Error[struct {
+	AccessDenied ProtectMemoryError `permission denied`      // mapped file [FileAccessMode] is incompatible with [MemoryProtection].
+	Invalid      ProtectMemoryError `invalid argument`       // addr is not aligned to page size, is invalid or flags are invalid.
+	OutOfMemory  ProtectMemoryError `cannot allocate memory` // kernel is out of memory
+}]
+
+// HeapError returned by [API.Heap] operations.
+type HeapError Error[struct {
+	OutOfMemory HeapError `cannot allocate memory` // no more memory available.
+}]
+
+// ReadDirectoryError returned by [API.ReadDirectory], [File.ReadDirectory] operations.
+type ReadDirectoryError Error[struct {
+	BadFile        ReadDirectoryError `bad file descriptor`       // file is not valid.
+	Fault          ReadDirectoryError `bad address`               // buffer is outside the accessible address space.
+	BufferTooSmall ReadDirectoryError `invalid argument`          // buffer is too small to hold the next entry.
+	NotFound       ReadDirectoryError `no such file or directory` // directory has been removed.
+	NotDirectory   ReadDirectoryError `not a directory`           // file is not a directory.
+}]
+
+// MakeDirectoryError returned by [API.MakeDirectory] operations.
+type MakeDirectoryError Error[struct {
+	AccessDenied   MakeDirectoryError `permission denied`                 // parent directory is not writable, or one of the directories is missing the search permission bit.
+	QuotaExhausted MakeDirectoryError `disk quota exceeded`               // user's quota of space or index nodes has run out.
+	AlreadyExists  MakeDirectoryError `file exists`                       // path already exists, it may not be a directory.
+	Fault          MakeDirectoryError `bad address`                       // pathname is outside your accessible address space.
+	Invalid        MakeDirectoryError `invalid argument`                  // final component of the path is invalid for the file system.
+	Loop           MakeDirectoryError `too many levels of symbolic links` // too many symbolic links.
+	TooManyLinks   MakeDirectoryError `too many links`                    // parent directory has too many links.
+	NameTooLong    MakeDirectoryError `file name too long`                // path is too long.
+	DoesNotExist   MakeDirectoryError `no such file or directory`         // a directory in the path does not exist.
+	OutOfMemory    MakeDirectoryError `cannot allocate memory`            // kernel is out of memory.
+	NoMoreSpace    MakeDirectoryError `no space left on device`           // device has no more space to create the directory.
+	NotDirectory   MakeDirectoryError `not a directory`                   // a component of the path prefix is not a directory.
+	NotPermitted   MakeDirectoryError `operation not permitted`           // file system does not support creating directories.
+	ReadOnly       MakeDirectoryError `read-only file system`             // path is on a read-only filesystem.
+}]
+
+// RemoveError returned by [API.RemoveFile] and [API.RemoveDirectory] operations.
+type RemoveError Error[struct {
+	AccessDenied  RemoveError `permission denied`                 // parent directory is not writable, or one of the directories is missing the search permission bit.
+	Busy          RemoveError `device or resource busy`           // path is in use by the system, for example as a mount point.
+	Fault         RemoveError `bad address`                       // pathname is outside your accessible address space.
+	IO            RemoveError `input/output error`                // an I/O error occurred.
+	Directory     RemoveError `is a directory`                    // path is a directory, use [API.RemoveDirectory].
+	Invalid       RemoveError `invalid argument`                  // last component of the path is ".".
+	Loop          RemoveError `too many levels of symbolic links` // too many symbolic links.
+	NameTooLong   RemoveError `file name too long`                // path is too long.
+	DoesNotExist  RemoveError `no such file or directory`         // path does not exist.
+	OutOfMemory   RemoveError `cannot allocate memory`            // kernel is out of memory.
+	NotDirectory  RemoveError `not a directory`                   // a component of the path is not a directory, or path is not a directory for [API.RemoveDirectory].
+	NotEmpty      RemoveError `directory not empty`               // directory contains entries other than "." and "..".
+	AlreadyExists RemoveError `file exists`                       // directory contains entries, on some file systems.
+	NotPermitted  RemoveError `operation not permitted`           // directory is locked to its owner with [FilesLockedToOwner], or the file system does not support removal.
+	ReadOnly      RemoveError `read-only file system`             // path is on a read-only filesystem.
+}]
+
+// RenameError returned by [API.Rename] operations.
+type RenameError Error[struct {
+	AccessDenied   RenameError `permission denied`                 // a parent directory is not writable, or one of the directories is missing the search permission bit.
+	Busy           RenameError `device or resource busy`           // either path is in use by the system, for example as a mount point.
+	QuotaExhausted RenameError `disk quota exceeded`               // user's quota of space has run out.
+	Fault          RenameError `bad address`                       // pathname is outside your accessible address space.
+	Invalid        RenameError `invalid argument`                  // directory would be moved inside itself, or the flags are invalid or unsupported.
+	Directory      RenameError `is a directory`                    // destination is a directory but the source is not.
+	Loop           RenameError `too many levels of symbolic links` // too many symbolic links.
+	TooManyLinks   RenameError `too many links`                    // destination directory has too many links.
+	NameTooLong    RenameError `file name too long`                // path is too long.
+	DoesNotExist   RenameError `no such file or directory`         // source does not exist, or a directory in either path does not exist.
+	OutOfMemory    RenameError `cannot allocate memory`            // kernel is out of memory.
+	NoMoreSpace    RenameError `no space left on device`           // device has no more space to extend the destination directory.
+	NotDirectory   RenameError `not a directory`                   // a component of either path is not a directory, or the source is a directory and the destination is not.
+	NotEmpty       RenameError `directory not empty`               // destination is a directory that is not empty.
+	AlreadyExists  RenameError `file exists`                       // destination exists and [RenameDoNotReplace] was used.
+	NotPermitted   RenameError `operation not permitted`           // directory is locked to its owner with [FilesLockedToOwner], or the file system does not support renaming.
+	ReadOnly       RenameError `read-only file system`             // path is on a read-only filesystem.
+	CrossDevice    RenameError `invalid cross-device link`         // paths are not on the same mounted file system.
+}]
+
+// LinkError returned by [API.LinkFile] operations.
+type LinkError Error[struct {
+	AccessDenied   LinkError `permission denied`                 // directory is not writable, or one of the directories is missing the search permission bit.
+	BadFile        LinkError `bad file descriptor`               // file is not valid.
+	QuotaExhausted LinkError `disk quota exceeded`               // user's quota of space has run out.
+	AlreadyExists  LinkError `file exists`                       // path already exists.
+	Fault          LinkError `bad address`                       // pathname is outside your accessible address space.
+	Invalid        LinkError `invalid argument`                  // invalid flags.
+	IO             LinkError `input/output error`                // an I/O error occurred.
+	Loop           LinkError `too many levels of symbolic links` // too many symbolic links.
+	TooManyLinks   LinkError `too many links`                    // file has too many links.
+	NameTooLong    LinkError `file name too long`                // path is too long.
+	DoesNotExist   LinkError `no such file or directory`         // a directory in the path does not exist, or the file has been removed and was not opened with [FileTemporaryInside].
+	OutOfMemory    LinkError `cannot allocate memory`            // kernel is out of memory.
+	NoMoreSpace    LinkError `no space left on device`           // device has no more space to extend the directory.
+	NotDirectory   LinkError `not a directory`                   // a component of the path prefix is not a directory.
+	NotPermitted   LinkError `operation not permitted`           // file is a directory, or the file system does not support links.
+	ReadOnly       LinkError `read-only file system`             // path is on a read-only filesystem.
+	CrossDevice    LinkError `invalid cross-device link`         // file and path are not on the same mounted file system.
+}]
+
+// SyncError returned by [API.Sync], [File.Sync] operations.
+type SyncError Error[struct {
+	BadFile        SyncError `bad file descriptor`     // file is not valid.
+	QuotaExhausted SyncError `disk quota exceeded`     // user's quota of space has run out.
+	Interrupted    SyncError `interrupted system call` // sync was interrupted by a signal.
+	Invalid        SyncError `invalid argument`        // file does not support synchronization.
+	IO             SyncError `input/output error`      // an I/O error occurred, data written since the last sync may have been lost.
+	NoMoreSpace    SyncError `no space left on device` // device has no more space to write the data.
+	ReadOnly       SyncError `read-only file system`   // file does not support synchronization.
+}]
+
+// EventError returned by [API.CreateEvent] operations.
+type EventError Error[struct {
+	Invalid              EventError `invalid argument`              // flags are not supported.
+	TooManyFiles         EventError `too many open files`           // process has too many files open.
+	TooManyFilesInSystem EventError `too many open files in system` // system has too many files open.
+	NoDevice             EventError `no such device`                // anonymous inode device could not be mounted.
+	OutOfMemory          EventError `cannot allocate memory`        // kernel is out of memory.
+}]
+
+// RemapError returned by [API.Remap], [MappedMemory.Remap] operations.
+type RemapError Error[struct {
+	Locked      RemapError `resource temporarily unavailable` // memory is locked and cannot be moved or grown.
+	Fault       RemapError `bad address`                      // range is not entirely mapped.
+	Invalid     RemapError `invalid argument`                 // address is not page-aligned, the flags are invalid, or the new length is zero.
+	OutOfMemory RemapError `cannot allocate memory`           // mapping cannot be grown in place without [RemapMayMove], or the range is not mapped.
+}]
+
+// AdviseMemoryError returned by [API.AdviseMemory], [MappedMemory.Advise] operations.
+type AdviseMemoryError Error[struct {
+	AccessDenied AdviseMemoryError `permission denied`                // advice requires write access to the mapped file.
+	TryAgain     AdviseMemoryError `resource temporarily unavailable` // kernel resources were temporarily unavailable.
+	BadFile      AdviseMemoryError `bad file descriptor`              // memory is not a mapped file.
+	Busy         AdviseMemoryError `device or resource busy`          // pages could not be collapsed, as they are in use.
+	Fault        AdviseMemoryError `bad address`                      // pages could not be populated.
+	Invalid      AdviseMemoryError `invalid argument`                 // address is not page-aligned, or the advice is not supported for the memory.
+	IO           AdviseMemoryError `input/output error`               // an I/O error occurred while reading ahead.
+	OutOfMemory  AdviseMemoryError `cannot allocate memory`           // range is not mapped, or the kernel is out of memory.
+	NotPermitted AdviseMemoryError `operation not permitted`          // advice requires privileges.
+}]
+
+// LockMemoryError returned by [API.LockMemory], [API.UnlockMemory], [MappedMemory.Lock], [MappedMemory.Unlock] operations.
+type LockMemoryError Error[struct {
+	TryAgain     LockMemoryError `resource temporarily unavailable` // some of the memory could not be locked.
+	Invalid      LockMemoryError `invalid argument`                 // flags are invalid, or the range overflows.
+	OutOfMemory  LockMemoryError `cannot allocate memory`           // range is not mapped, or locking would exceed RLIMIT_MEMLOCK.
+	NotPermitted LockMemoryError `operation not permitted`          // process is not allowed to lock memory.
+}]
+
+// MemoryResidencyError returned by [API.MemoryResidency], [MappedMemory.Residency] operations.
+type MemoryResidencyError Error[struct {
+	TryAgain    MemoryResidencyError `resource temporarily unavailable` // kernel resources were temporarily unavailable.
+	Fault       MemoryResidencyError `bad address`                      // pages buffer is outside the accessible address space.
+	Invalid     MemoryResidencyError `invalid argument`                 // address is not page-aligned, or the pages buffer is too small.
+	OutOfMemory MemoryResidencyError `cannot allocate memory`           // range is not mapped.
+}]
+
+// SyncMemoryError returned by [API.SyncMemory], [MappedMemory.Sync] operations.
+type SyncMemoryError Error[struct {
+	Busy        SyncMemoryError `device or resource busy` // [MemorySyncInvalidate] was used on locked memory.
+	Invalid     SyncMemoryError `invalid argument`        // address is not page-aligned, or the flags are invalid.
+	OutOfMemory SyncMemoryError `cannot allocate memory`  // range is not mapped.
+}]
+
+// MemoryFileError returned by [API.CreateMemoryFile] operations.
+type MemoryFileError Error[struct {
+	Fault                MemoryFileError `bad address`                   // name is outside the accessible address space.
+	Invalid              MemoryFileError `invalid argument`              // flags are not supported, or the name is too long.
+	TooManyFiles         MemoryFileError `too many open files`           // process has too many files open.
+	TooManyFilesInSystem MemoryFileError `too many open files in system` // system has too many files open.
+	OutOfMemory          MemoryFileError `cannot allocate memory`        // kernel is out of memory.
+	NotPermitted         MemoryFileError `operation not permitted`       // [MemoryFileHugeTables] requires privileges.
+}]
+
+// TruncateError returned by [API.Truncate], [File.Truncate] operations.
+type TruncateError Error[struct {
+	BadFile      TruncateError `bad file descriptor`     // file is not valid or not open for writing.
+	TooLarge     TruncateError `file too large`          // size is larger than the maximum file size.
+	Interrupted  TruncateError `interrupted system call` // truncate was interrupted by a signal.
+	Invalid      TruncateError `invalid argument`        // size is negative, or the file is not a regular file open for writing.
+	IO           TruncateError `input/output error`      // an I/O error occurred.
+	NotPermitted TruncateError `operation not permitted` // file is append-only, immutable or sealed.
+	ReadOnly     TruncateError `read-only file system`   // file is on a read-only filesystem.
+	FileInUse    TruncateError `text file busy`          // file is an executable that is being executed.
+}]
+
+// FutexError returned by [API.WaitFutex], [API.WakeFutex] and the other futex operations.
+type FutexError Error[struct {
+	AccessDenied   FutexError `permission denied`                // futex is not readable.
+	WouldBlock     FutexError `resource temporarily unavailable` // futex did not hold the expected value.
+	Fault          FutexError `bad address`                      // futex or timeout is outside the accessible address space.
+	Interrupted    FutexError `interrupted system call`          // wait was interrupted by a signal, or woke up spuriously.
+	Invalid        FutexError `invalid argument`                 // futex is misaligned, the timeout or flags are invalid, or the bitset is zero.
+	OutOfMemory    FutexError `cannot allocate memory`           // kernel is out of memory.
+	NotImplemented FutexError `function not implemented`         // operation is not supported by the kernel.
+	TimedOut       FutexError `connection timed out`             // timeout expired before the futex was woken.
+}]
+
+// MutexError returned by [Mutex] and [Condition] operations.
+type MutexError Error[struct {
+	Invalid        MutexError `invalid argument`         // mutex is not in an inconsistent state.
+	NotPermitted   MutexError `operation not permitted`  // mutex is not locked by this process.
+	OwnerDied      MutexError `owner died`               // previous owner exited while holding the mutex, which is now locked but inconsistent, see [Mutex.Consistent].
+	NotRecoverable MutexError `state not recoverable`    // mutex was unlocked while inconsistent, and can no longer be locked.
+	NotImplemented MutexError `function not implemented` // robust futexes are not supported by the kernel.
+}]
+
+// ClockError returned by [API.ClockTime], [API.ClockResolution] operations.
+type ClockError Error[struct {
+	Fault   ClockError `bad address`      // time is outside the accessible address space.
+	Invalid ClockError `invalid argument` // clock is not supported.
+}]
+
+// SleepError returned by [API.Sleep] operations.
+type SleepError Error[struct {
+	Fault       SleepError `bad address`             // time is outside the accessible address space.
+	Interrupted SleepError `interrupted system call` // sleep was interrupted by a signal.
+	Invalid     SleepError `invalid argument`        // time is negative or has more than a second of nanoseconds, or the clock is not supported.
+	Unsupported SleepError `operation not supported` // clock cannot be slept on, such as [ClockThreadTime].
+}]
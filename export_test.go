@@ -0,0 +1,11 @@
+package linux
+
+// ErrorTypes returns the Types of each generated error type, by the name of
+// the type.
+func ErrorTypes() map[string]any {
+	var types = make(map[string]any, len(errorTypes))
+	for name, table := range errorTypes {
+		types[name] = table.types
+	}
+	return types
+}
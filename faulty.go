@@ -0,0 +1,130 @@
+package linux
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// FaultPlan configures the faults injected by [Faulty].
+type FaultPlan struct {
+	Seed   uint64  // seed for probabilistic faults, the same seed and calls inject the same faults.
+	Faults []Fault // faults to inject, every matching fault is applied to a call.
+}
+
+// Fault to inject into matching calls, by default a fault matches every call.
+type Fault struct {
+	Call        string                    // name of the [API] function to inject into, for example "Write".
+	Path        func(Path) bool           // only inject into calls on a matching path.
+	File        func(FileDescriptor) bool // only inject into calls on a matching file.
+	Nth         int                       // only inject into the nth matching call, starting at 1.
+	Probability float64                   // chance of injecting into a matching call, zero means always.
+
+	Err     error         // error to return, instead of making the call.
+	Short   Bytes         // limit [API.Read] and [API.Write] to this many bytes, for short reads and writes.
+	Latency time.Duration // delay before making the call.
+}
+
+// Faulty returns an [API] that injects the faults in the plan into calls made
+// through it, before passing them through to api. Faults can inject the errors
+// already returned by the api, for example [WriteError.NoMoreSpace] or
+// [ReadError.Interrupted]. A call with an injected error is not made, except
+// for [API.Close], which releases the descriptor before the error is returned,
+// as it does on Linux.
+func Faulty(api *API, plan FaultPlan) *API {
+	var faulty = new(API)
+	var mu sync.Mutex
+	var random = rand.New(rand.NewPCG(plan.Seed, plan.Seed))
+	var counts = make([]int, len(plan.Faults))
+	var inner = reflect.ValueOf(api).Elem()
+	var outer = reflect.ValueOf(faulty).Elem()
+	for i := range outer.NumField() {
+		var field = outer.Type().Field(i)
+		var fn = inner.Field(i)
+		if fn.IsNil() {
+			continue
+		}
+		outer.Field(i).Set(reflect.MakeFunc(field.Type, func(args []reflect.Value) []reflect.Value {
+			var err error
+			var short Bytes = -1
+			var latency time.Duration
+			mu.Lock()
+			for i, fault := range plan.Faults {
+				if !fault.matches(field.Name, args) {
+					continue
+				}
+				counts[i]++
+				if fault.Nth > 0 && counts[i] != fault.Nth {
+					continue
+				}
+				if fault.Probability > 0 && random.Float64() >= fault.Probability {
+					continue
+				}
+				if err == nil {
+					err = fault.Err
+				}
+				if fault.Short > 0 && (short < 0 || fault.Short < short) {
+					short = fault.Short
+				}
+				latency += fault.Latency
+			}
+			mu.Unlock()
+			time.Sleep(latency)
+			// only the buffer of reads and writes, other buffers such as those of
+			// [API.ReadDirectory] are not allowed to be short.
+			if short >= 0 && (field.Name == "Read" || field.Name == "Write") && args[1].Len() > int(short) {
+				args[1] = args[1].Slice(0, int(short))
+			}
+			var results []reflect.Value
+			if err == nil || field.Name == "Close" {
+				results = fn.Call(args)
+			} else {
+				results = make([]reflect.Value, field.Type.NumOut())
+				for i := range results {
+					results[i] = reflect.Zero(field.Type.Out(i))
+				}
+			}
+			for i, result := range results {
+				switch result.Type() {
+				case reflect.TypeFor[File]():
+					if err == nil {
+						results[i] = rebind(result, api, faulty)
+						continue
+					}
+					results[i] = reflect.New(result.Type()).Elem()
+					results[i].FieldByName("Linux").Set(reflect.ValueOf(faulty))
+					results[i].FieldByName("Descriptor").SetInt(-1)
+				case reflect.TypeFor[error]():
+					if err != nil {
+						results[i] = reflect.ValueOf(&err).Elem()
+					}
+				}
+			}
+			return results
+		}))
+	}
+	return faulty
+}
+
+func (fault *Fault) matches(call string, args []reflect.Value) bool {
+	if fault.Call != "" && fault.Call != call {
+		return false
+	}
+	if fault.Path == nil && fault.File == nil {
+		return true
+	}
+	for _, arg := range args {
+		switch v := arg.Interface().(type) {
+		case Path:
+			if fault.Path != nil && fault.Path(v) {
+				return true
+			}
+		case FileDescriptor:
+			if fault.File != nil && fault.File(v) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package linux_test
+
+import (
+	"testing"
+
+	"verbose.style/linux"
+)
+
+func TestFaulty(t *testing.T) {
+	var Linux = linux.Faulty(linux.Memory(), linux.FaultPlan{
+		Faults: []linux.Fault{
+			{Call: "Write", Nth: 2, Err: new(linux.WriteError).Types().NoMoreSpace},
+			{Call: "Write", Short: 3},
+			{Call: "Open", Path: func(name linux.Path) bool { return name == "/denied" }, Err: new(linux.OpenError).Types().AccessDenied},
+			{Call: "Close", Err: new(linux.CloseError).Types().QuotaExhausted},
+		},
+	})
+	if _, err := Linux.Open("/denied", linux.FileAccessReadOnly, linux.FileCreateIfNeeded, 0, 0600); err != new(linux.OpenError).Types().AccessDenied {
+		t.Fatal(err)
+	}
+	f, err := Linux.Open("/file", linux.FileAccessReadWrite, linux.FileCreateIfNeeded, 0, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := f.Write([]byte("hello")); n != 3 || err != nil {
+		t.Fatal(n, err)
+	}
+	if _, err := f.Write([]byte("hello")); err != new(linux.WriteError).Types().NoMoreSpace {
+		t.Fatal(err)
+	}
+	if header, err := f.Stat(); err != nil || header.Size != 3 {
+		t.Fatal(header.Size, err)
+	}
+	if err := f.Close(); err != new(linux.CloseError).Types().QuotaExhausted {
+		t.Fatal(err)
+	}
+	if _, err := f.Stat(); err != new(linux.StatError).Types().BadFile {
+		t.Fatal("descriptor was not released", err)
+	}
+
+	var faults = func(seed uint64) (injected []bool) {
+		var Linux = linux.Faulty(linux.Memory(), linux.FaultPlan{Seed: seed, Faults: []linux.Fault{
+			{Call: "Read", Probability: 0.5, Err: new(linux.ReadError).Types().IO},
+		}})
+		for range 32 {
+			_, err := Linux.Read(0, nil)
+			injected = append(injected, err == new(linux.ReadError).Types().IO)
+		}
+		return injected
+	}
+	var a, b, c = faults(1), faults(1), faults(2)
+	var same, differs = true, false
+	for i := range a {
+		same = same && a[i] == b[i]
+		differs = differs || a[i] != c[i]
+	}
+	if !same || !differs {
+		t.Fatal("faults are not reproducible from the seed", a, b, c)
+	}
+}
+
+func TestFaultyShort(t *testing.T) {
+	var Linux = linux.Faulty(linux.Memory(), linux.FaultPlan{
+		Faults: []linux.Fault{{Short: 1}},
+	})
+	if err := Linux.MakeDirectory("/directory", 0700); err != nil {
+		t.Fatal(err)
+	}
+	f, err := Linux.Open("/file", linux.FileAccessReadWrite, linux.FileCreateIfNeeded, 0, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if n, err := f.Write([]byte("hello")); n != 1 || err != nil {
+		t.Fatal(n, err)
+	}
+	directory, err := Linux.Open("/", linux.FileAccessReadOnly, linux.FileAssertDirectory, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer directory.Close()
+	var buf [4096]byte
+	if n, err := Linux.ReadDirectory(directory.Descriptor, buf[:]); n == 0 || err != nil {
+		t.Fatal("directory reads should not be short", n, err)
+	}
+}
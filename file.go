@@ -1,9 +1,8 @@
 package linux
 
 import (
-	"structs"
+	"errors"
 	"sync/atomic"
-	"syscall"
 )
 
 // File opened with [API].
@@ -11,20 +10,20 @@ type File struct {
 	Linux      *API
 	Descriptor FileDescriptor
 	Closed     atomic.Bool
+
+	runtime    atomic.Pointer[runtimeFile] // registration with the runtime's poller, see [File.SetDeadline].
+	unpollable atomic.Bool                 // the runtime's poller cannot wait on the file.
 }
 
 // FileHeader returned by [API.Stat] provides a representation of the metadata that
 // the filesystem records on the file.
-type FileHeader struct { //cc:stat
-	_ structs.HostLayout
-
+type FileHeader struct {
 	Device      DeviceID
 	IndexNode   IndexNode
 	HardLinks   uint64
 	Permissions FilePermissions
 	User        UserID
 	Group       GroupID
-	_           int32
 	Special     DeviceID
 	Size        Bytes
 	BlockSize   Bytes
@@ -33,21 +32,40 @@ type FileHeader struct { //cc:stat
 	AccessedAt         Time
 	ModifiedAt         Time
 	ModifiedMetadataAt Time
-	_                  [3]int64
 }
 
 // FileDescriptor identifies an open file for the process.
 type FileDescriptor int32
 
-// Read implements [io.Reader]
+// Read implements [io.Reader], files opened with [FileNonBlocking] park the
+// goroutine until the file is ready, instead of returning [ReadError.WouldBlock],
+// or until the deadline set by [File.SetReadDeadline].
 func (f *File) Read(p []byte) (int, error) {
+	if runtime := f.runtime.Load(); runtime != nil {
+		return runtime.read(f, p)
+	}
 	n, err := f.Linux.Read(f.Descriptor, p)
+	if err != nil && errors.Is(err, new(ReadError).Types().WouldBlock) {
+		if runtime := f.register(); runtime != nil {
+			return runtime.read(f, p)
+		}
+	}
 	return int(n), err
 }
 
-// Write implements [io.Writer]
+// Write implements [io.Writer], files opened with [FileNonBlocking] park the
+// goroutine until the file is ready, instead of returning [WriteError.WouldBlock],
+// or until the deadline set by [File.SetWriteDeadline].
 func (f *File) Write(p []byte) (int, error) {
+	if runtime := f.runtime.Load(); runtime != nil {
+		return runtime.write(f, p)
+	}
 	n, err := f.Linux.Write(f.Descriptor, p)
+	if err != nil && errors.Is(err, new(WriteError).Types().WouldBlock) {
+		if runtime := f.register(); runtime != nil {
+			return runtime.write(f, p)
+		}
+	}
 	return int(n), err
 }
 
@@ -68,9 +86,19 @@ func (f *File) MapIntoMemory(mtype MapType, prot MemoryProtection, flags Map) (M
 	return f.Linux.MapIntoMemory(nil, int(head.Size), prot, mtype, flags, f.Descriptor, 0)
 }
 
-// Close the file.
+// Truncate changes the size of the file, see [API.Truncate].
+func (f *File) Truncate(size int64) error { return f.Linux.Truncate(f.Descriptor, size) }
+
+// Sync flushes the file to the underlying storage device, see [API.Sync].
+func (f *File) Sync() error { return f.Linux.Sync(f.Descriptor) }
+
+// Close the file, any goroutines parked in [File.Read] or [File.Write] return
+// [os.ErrClosed].
 func (f *File) Close() error {
 	if !f.Closed.Swap(true) {
+		if runtime := f.runtime.Load(); runtime != nil {
+			runtime.file.Close()
+		}
 		return f.Linux.Close(f.Descriptor)
 	}
 	return nil
@@ -79,69 +107,4 @@ func (f *File) Close() error {
 // MaxRead is the maximum number of bytes that can be read in a single call to [File.Read].
 const MaxRead Bytes = 0x7ffff000
 
-// FileCreationFlags affect the semantics of the [Kernel.Open] operation.
-type FileCreationFlags int
-
-const (
-	FileCloseOnExecute   FileCreationFlags = 0x80000  // close the file automatically on [Kernel.Execute].
-	FileCreateIfNeeded   FileCreationFlags = 0x40     // create the file if it does not exist.
-	FileAssertDirectory  FileCreationFlags = 0x10000  // fail to open if the path is not a directory.
-	FileAssertCreation   FileCreationFlags = 0x80     // fail to open if the file already exists.
-	FileIsNotTheTerminal FileCreationFlags = 0x100    // if the pathname is a terminal, it shouldn't become the controlling terminal for the process.
-	FileTrapSymbolicLink FileCreationFlags = 0x20000  // if the trailing component is a symbolic link, don't follow it, open it directly.
-	FileTemporaryInside  FileCreationFlags = 0x410000 // creates an unnamed temporary file inside the provided directory
-	FileTruncatedToZero  FileCreationFlags = 0x200    // resets the file to length 0, writes will overwrite any existing content.
-)
-
-// FileStatusFlags affect the semantics of subsequent I/O operations. These can be retrieved and (in some cases) modified;
-// see [File.Status] for details.
-type FileStatusFlags int
-
-const (
-	FileAppend                FileStatusFlags = 0x400    // append data to the end of the file when writing.
-	FileAsync                 FileStatusFlags = 0x2000   // emit [SignalIO] whenever input or output becomes available.
-	FileDirect                FileStatusFlags = 0x4000   // avoid cache where possible and use underlying hardware directly
-	FileSyncData              FileStatusFlags = 0x1000   // all [File.Write] operations are automatically followed by a [File.SyncData].
-	FileDoNotUpdateAccessTime FileStatusFlags = 0x40000  // request that the access time of the file is not updated on [File.Read]
-	FileNonBlocking           FileStatusFlags = 0x800    // return "resource temporarily unavailable" if a read/write would block
-	FilePath                  FileStatusFlags = 0x200000 // file is opened as a reference-only, no read/write operations are allowed.
-	FileSync                  FileStatusFlags = 0x101000 // all [File.Write] operations are automatically followed by a [File.Sync].
-)
-
-// FilePermissions mode bits.
-type FilePermissions uint32
-
-const (
-	FileReadableByUser   FilePermissions = syscall.S_IRUSR // file is readable by its owner
-	FileReadableByGroup  FilePermissions = syscall.S_IRGRP // file is readable by its group
-	FileReadableByOthers FilePermissions = syscall.S_IROTH // file is readable by others
-
-	FileWritableByUser   FilePermissions = syscall.S_IWUSR // file is writable by its owner
-	FileWritableByGroup  FilePermissions = syscall.S_IWGRP // file is writable by its group
-	FileWritableByOthers FilePermissions = syscall.S_IWOTH // file is writable by others
-
-	FileExecutableByUser   FilePermissions = syscall.S_IXUSR // file is executable by its owner
-	FileExecutableByGroup  FilePermissions = syscall.S_IXGRP // file is executable by its group
-	FileExecutableByOthers FilePermissions = syscall.S_IXOTH // file is executable by others
-
-	FileExecutesAsOwner FilePermissions = syscall.S_ISUID // file will be executed as if it were executed by the owner of the file
-	FileExecutesAsGroup FilePermissions = syscall.S_ISGID // file will be executed as if it were executed by the group of the file
-
-	FilesInheritGroup  FilePermissions = syscall.S_ISGID // files created in this directory inherit their group ID from the directory
-	FilesLockedToOwner FilePermissions = syscall.S_ISVTX // files in this directory can only be renamed or deleted by owners.
-
-	DirectorySearchableByUser   FilePermissions = syscall.S_IXUSR // directory is searchable by its owner
-	DirectorySearchableByGroup  FilePermissions = syscall.S_IXGRP // directory is searchable by its group
-	DirectorySearchableByOthers FilePermissions = syscall.S_IXOTH // directory is searchable by others
-)
-
-// FileAccessMode request opening the file read-only, write-only, or read/write, respectively.
-type FileAccessMode int
-
-const (
-	FileAccessReadOnly  FileAccessMode = syscall.O_RDONLY // enable reads
-	FileAccessWriteOnly FileAccessMode = syscall.O_WRONLY // enable writes
-	FileAccessReadWrite FileAccessMode = syscall.O_RDWR   // enable both reads and writes
-)
-
 const FileRelativeToWorkingDirectory = -100 // AT_FDCWD
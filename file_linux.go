@@ -0,0 +1,135 @@
+package linux
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"time"
+)
+
+// runtimeFile registers a [File] with the runtime's poller, through a
+// duplicate of its descriptor, which shares its readiness. Reads and writes
+// are still made through the [API] of the file.
+type runtimeFile struct {
+	file *os.File
+	conn syscall.RawConn
+}
+
+// register the file with the runtime's poller, returns nil if the file was
+// not opened with [FileNonBlocking], if the poller cannot wait on it, such as
+// for regular files, or if the descriptor of the file is not a kernel
+// descriptor for the same file, as is the case for [Memory]. Such files are
+// only checked once.
+func (f *File) register() *runtimeFile {
+	if runtime := f.runtime.Load(); runtime != nil {
+		return runtime
+	}
+	if f.Closed.Load() || f.unpollable.Load() {
+		return nil
+	}
+	if !f.pollable() {
+		f.unpollable.Store(true)
+		return nil
+	}
+	fd, _, errno := syscall.Syscall(syscall.SYS_FCNTL, uintptr(f.Descriptor), syscall.F_DUPFD_CLOEXEC, 0)
+	if errno != 0 {
+		return nil
+	}
+	var file = os.NewFile(fd, "")
+	// files that the poller rejects have no deadlines.
+	if err := file.SetDeadline(time.Time{}); err != nil {
+		file.Close()
+		f.unpollable.Store(true)
+		return nil
+	}
+	conn, err := file.SyscallConn()
+	if err != nil {
+		file.Close()
+		return nil
+	}
+	var runtime = &runtimeFile{file: file, conn: conn}
+	if !f.runtime.CompareAndSwap(nil, runtime) {
+		file.Close()
+	}
+	return f.runtime.Load()
+}
+
+// pollable reports whether the file is a non-blocking kernel descriptor of a
+// type that the runtime's poller may be able to wait on, see [File.register].
+func (f *File) pollable() bool {
+	header, err := f.Linux.StatFile(f.Descriptor)
+	if err != nil {
+		return false
+	}
+	switch header.Type() {
+	case FileTypeRegular, FileTypeDirectory, FileTypeBlockDevice:
+		return false
+	}
+	var stat syscall.Stat_t
+	if err := syscall.Fstat(int(f.Descriptor), &stat); err != nil || uint64(stat.Dev) != uint64(header.Device) || uint64(stat.Ino) != uint64(header.IndexNode) {
+		return false
+	}
+	status, _, errno := syscall.Syscall(syscall.SYS_FCNTL, uintptr(f.Descriptor), syscall.F_GETFL, 0)
+	return errno == 0 && FileStatusFlags(status)&FileNonBlocking != 0
+}
+
+func (runtime *runtimeFile) read(f *File, p []byte) (int, error) {
+	var n Bytes
+	var err error
+	if cerr := runtime.conn.Read(func(uintptr) bool {
+		n, err = f.Linux.Read(f.Descriptor, p)
+		return err == nil || !errors.Is(err, new(ReadError).Types().WouldBlock)
+	}); cerr != nil {
+		return 0, runtime.error(f, cerr)
+	}
+	return int(n), err
+}
+
+func (runtime *runtimeFile) write(f *File, p []byte) (int, error) {
+	var n Bytes
+	var err error
+	if cerr := runtime.conn.Write(func(uintptr) bool {
+		n, err = f.Linux.Write(f.Descriptor, p)
+		return err == nil || !errors.Is(err, new(WriteError).Types().WouldBlock)
+	}); cerr != nil {
+		return 0, runtime.error(f, cerr)
+	}
+	return int(n), err
+}
+
+// error returned by the runtime's poller, which does not report a file closed
+// while waiting as [os.ErrClosed].
+func (runtime *runtimeFile) error(f *File, err error) error {
+	if f.Closed.Load() && !errors.Is(err, os.ErrDeadlineExceeded) {
+		return os.ErrClosed
+	}
+	return err
+}
+
+// SetDeadline sets the read and write deadlines of the file, after which
+// [File.Read] and [File.Write] fail with an error that matches
+// [os.ErrDeadlineExceeded], a zero time means no deadline. Only files opened
+// with [FileNonBlocking] that the runtime can poll, such as pipes and sockets,
+// support deadlines, otherwise [os.ErrNoDeadline] is returned.
+func (f *File) SetDeadline(t time.Time) error {
+	if runtime := f.register(); runtime != nil {
+		return runtime.file.SetDeadline(t)
+	}
+	return os.ErrNoDeadline
+}
+
+// SetReadDeadline sets the deadline for [File.Read], see [File.SetDeadline].
+func (f *File) SetReadDeadline(t time.Time) error {
+	if runtime := f.register(); runtime != nil {
+		return runtime.file.SetReadDeadline(t)
+	}
+	return os.ErrNoDeadline
+}
+
+// SetWriteDeadline sets the deadline for [File.Write], see [File.SetDeadline].
+func (f *File) SetWriteDeadline(t time.Time) error {
+	if runtime := f.register(); runtime != nil {
+		return runtime.file.SetWriteDeadline(t)
+	}
+	return os.ErrNoDeadline
+}
@@ -0,0 +1,35 @@
+//go:build !linux
+
+package linux
+
+import (
+	"os"
+	"time"
+)
+
+// runtimeFile is only registered with the runtime's poller on Linux.
+type runtimeFile struct {
+	file *os.File
+}
+
+func (f *File) register() *runtimeFile { return nil }
+
+func (runtime *runtimeFile) read(f *File, p []byte) (int, error) {
+	n, err := f.Linux.Read(f.Descriptor, p)
+	return int(n), err
+}
+
+func (runtime *runtimeFile) write(f *File, p []byte) (int, error) {
+	n, err := f.Linux.Write(f.Descriptor, p)
+	return int(n), err
+}
+
+// SetDeadline returns [os.ErrNoDeadline], as deadlines are only supported on
+// Linux.
+func (f *File) SetDeadline(t time.Time) error { return os.ErrNoDeadline }
+
+// SetReadDeadline returns [os.ErrNoDeadline], see [File.SetDeadline].
+func (f *File) SetReadDeadline(t time.Time) error { return os.ErrNoDeadline }
+
+// SetWriteDeadline returns [os.ErrNoDeadline], see [File.SetDeadline].
+func (f *File) SetWriteDeadline(t time.Time) error { return os.ErrNoDeadline }
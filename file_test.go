@@ -0,0 +1,157 @@
+package linux_test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"verbose.style/linux"
+)
+
+func nonBlockingPipe(t *testing.T) (r, w *linux.File) {
+	t.Helper()
+	var fds [2]int
+	if err := syscall.Pipe2(fds[:], syscall.O_NONBLOCK|syscall.O_CLOEXEC); err != nil {
+		t.Fatal(err)
+	}
+	api := linux.Native()
+	r = &linux.File{Linux: api, Descriptor: linux.FileDescriptor(fds[0])}
+	w = &linux.File{Linux: api, Descriptor: linux.FileDescriptor(fds[1])}
+	t.Cleanup(func() { r.Close(); w.Close() })
+	return r, w
+}
+
+func TestFileNonBlocking(t *testing.T) {
+	r, w := nonBlockingPipe(t)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		w.Write([]byte("hello"))
+	}()
+	var buf [5]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		t.Fatal(err)
+	}
+	if string(buf[:]) != "hello" {
+		t.Fatalf("read %q", buf)
+	}
+}
+
+func TestFileDeadline(t *testing.T) {
+	r, _ := nonBlockingPipe(t)
+	if err := r.SetReadDeadline(time.Now().Add(10 * time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+	var buf [1]byte
+	if _, err := r.Read(buf[:]); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if err := r.SetReadDeadline(time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFileCloseUnblocks(t *testing.T) {
+	r, _ := nonBlockingPipe(t)
+	done := make(chan error)
+	go func() {
+		var buf [1]byte
+		_, err := r.Read(buf[:])
+		done <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	r.Close()
+	select {
+	case err := <-done:
+		if !errors.Is(err, os.ErrClosed) {
+			t.Fatalf("expected closed, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("read did not return after close")
+	}
+}
+
+func TestFileNoDeadline(t *testing.T) {
+	for name, api := range map[string]*linux.API{
+		"Native": linux.Native(),
+		"Memory": linux.Memory(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			if name == "Memory" {
+				dir = ""
+			}
+			file, err := api.Open(linux.Path(dir+"/file"), linux.FileAccessReadWrite, linux.FileCreateIfNeeded, 0, 0644)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+			if err := file.SetDeadline(time.Now()); !errors.Is(err, os.ErrNoDeadline) {
+				t.Fatalf("expected no deadline, got %v", err)
+			}
+		})
+	}
+}
+
+func TestFileNoRegistration(t *testing.T) {
+	var api = linux.Native()
+	var fds [2]int
+	if err := syscall.Pipe2(fds[:], syscall.O_CLOEXEC); err != nil {
+		t.Fatal(err)
+	}
+	var blocking = &linux.File{Linux: api, Descriptor: linux.FileDescriptor(fds[0])}
+	defer blocking.Close()
+	defer syscall.Close(fds[1])
+	regular, err := api.Open(linux.Path(t.TempDir()+"/file"), linux.FileAccessReadWrite, linux.FileCreateIfNeeded, linux.FileNonBlocking, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer regular.Close()
+	for name, file := range map[string]*linux.File{"Blocking": blocking, "Regular": &regular} {
+		t.Run(name, func(t *testing.T) {
+			before, err := os.ReadDir("/proc/self/fd")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := file.SetDeadline(time.Now()); !errors.Is(err, os.ErrNoDeadline) {
+				t.Fatalf("expected no deadline, got %v", err)
+			}
+			after, err := os.ReadDir("/proc/self/fd")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(after) != len(before) {
+				t.Fatalf("%d descriptors were left open", len(after)-len(before))
+			}
+		})
+	}
+}
+
+func TestFileRegistrationOnce(t *testing.T) {
+	var api = linux.Memory()
+	var stats int
+	var statFile = api.StatFile
+	api.StatFile = func(fd linux.FileDescriptor) (linux.FileHeader, error) {
+		stats++
+		return statFile(fd)
+	}
+	event, err := api.CreateEvent(0, linux.EventNonBlocking)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer event.Close()
+	var buf [8]byte
+	for range 3 {
+		if _, err := event.Read(buf[:]); !errors.Is(err, new(linux.ReadError).Types().WouldBlock) {
+			t.Fatalf("expected would block, got %v", err)
+		}
+	}
+	if err := event.SetDeadline(time.Now()); !errors.Is(err, os.ErrNoDeadline) {
+		t.Fatalf("expected no deadline, got %v", err)
+	}
+	if stats != 1 {
+		t.Fatalf("file was checked for registration %d times", stats)
+	}
+}
@@ -0,0 +1,652 @@
+// Code generated by "go run ./internal/flagsgen"; DO NOT EDIT.
+
+package linux
+
+// MemoryProtection is used by [API.MapIntoMemory] and [API.ProtectMemory].
+type MemoryProtection int
+
+const (
+	MemoryNotAccessible  MemoryProtection = 0x0 // no access allowed.
+	MemoryAllowReads     MemoryProtection = 0x1 // read access allowed.
+	MemoryAllowWrites    MemoryProtection = 0x2 // write access allowed.
+	MemoryAllowExecution MemoryProtection = 0x4 // execute access allowed.
+	MemoryAllowAtomics   MemoryProtection = 0x8 // atomic operations allowed.
+)
+
+var memoryProtectionNames = []flagName[MemoryProtection]{
+	{"MemoryAllowReads", MemoryAllowReads},
+	{"MemoryAllowWrites", MemoryAllowWrites},
+	{"MemoryAllowExecution", MemoryAllowExecution},
+	{"MemoryAllowAtomics", MemoryAllowAtomics},
+	{"MemoryNotAccessible", MemoryNotAccessible},
+}
+
+func (v MemoryProtection) String() string {
+	return formatFlags(v, "MemoryNotAccessible", memoryProtectionNames)
+}
+
+// ParseMemoryProtection parses names of [MemoryProtection] separated by '|', as formatted by [MemoryProtection.String].
+func ParseMemoryProtection(s string) (MemoryProtection, error) {
+	return parseFlags("MemoryProtection", s, memoryProtectionNames)
+}
+
+func (v MemoryProtection) MarshalText() ([]byte, error) { return []byte(v.String()), nil }
+
+func (v *MemoryProtection) UnmarshalText(text []byte) (err error) {
+	*v, err = ParseMemoryProtection(string(text))
+	return err
+}
+
+// MapType is used by [API.MapIntoMemory].
+type MapType int
+
+const (
+	MapShared              MapType = 0x1 // persist writes back to the file.
+	MapPrivate             MapType = 0x2 // copy-on-write memory.
+	MapSharedValidateFlags MapType = 0x3 // [MapShared] + validate flags.
+)
+
+var mapTypeNames = []flagName[MapType]{
+	{"MapShared", MapShared},
+	{"MapPrivate", MapPrivate},
+	{"MapSharedValidateFlags", MapSharedValidateFlags},
+}
+
+func (v MapType) String() string { return formatEnum(v, mapTypeNames) }
+
+// ParseMapType parses the name of a [MapType], as formatted by [MapType.String].
+func ParseMapType(s string) (MapType, error) { return parseEnum("MapType", s, mapTypeNames) }
+
+func (v MapType) MarshalText() ([]byte, error) { return []byte(v.String()), nil }
+
+func (v *MapType) UnmarshalText(text []byte) (err error) {
+	*v, err = ParseMapType(string(text))
+	return err
+}
+
+// Map flags are used by [API.MapIntoMemory].
+type Map int
+
+const (
+	MapAnonymous        Map = 0x20       // file must be -1, just allocate anonymous memory.
+	MapExactAddress     Map = 0x10       // addr must be page-aligned and will be used directly.
+	MapExactAddressOnce Map = 0x100000   // like [MapExactAddress] but goroutine-safe.
+	MapGrowsDown        Map = 0x100      // touching the first page will grow the mapping down by a single page.
+	MapHugeTables       Map = 0x40000    // use huge pages.
+	MapHuge2MB          Map = 0x54000000 // use 2MB huge pages.
+	MapHuge1GB          Map = 0x78000000 // use 1GB huge pages.
+	MapKeepAwayFromSwap Map = 0x2000     // lock the pages in physical memory (do not swap).
+	MapDoNotReserveSwap Map = 0x4000     // do not reserve swap space.
+	MapPopulate         Map = 0x8000     // eagerly load the file into the map.
+	MapStack            Map = 0x20000    // ensure memory is suitably setup to use for a stack.
+	MapSync             Map = 0x80000    // for files that support direct mapping of persistent memory.
+	MapUninitialized    Map = 0x4000000  // don't zero out pages, subject to the system security policy.
+)
+
+var mapNames = []flagName[Map]{
+	{"MapHuge1GB", MapHuge1GB},
+	{"MapHuge2MB", MapHuge2MB},
+	{"MapAnonymous", MapAnonymous},
+	{"Map32Bit", Map32Bit},
+	{"MapExactAddress", MapExactAddress},
+	{"MapExactAddressOnce", MapExactAddressOnce},
+	{"MapGrowsDown", MapGrowsDown},
+	{"MapHugeTables", MapHugeTables},
+	{"MapKeepAwayFromSwap", MapKeepAwayFromSwap},
+	{"MapDoNotReserveSwap", MapDoNotReserveSwap},
+	{"MapPopulate", MapPopulate},
+	{"MapStack", MapStack},
+	{"MapSync", MapSync},
+	{"MapUninitialized", MapUninitialized},
+}
+
+func (v Map) String() string { return formatFlags(v, "0", mapNames) }
+
+// ParseMap parses names of [Map] separated by '|', as formatted by [Map.String].
+func ParseMap(s string) (Map, error) { return parseFlags("Map", s, mapNames) }
+
+func (v Map) MarshalText() ([]byte, error) { return []byte(v.String()), nil }
+
+func (v *Map) UnmarshalText(text []byte) (err error) {
+	*v, err = ParseMap(string(text))
+	return err
+}
+
+// Poll events that can be polled for.
+type Poll int16
+
+const (
+	PollHasReadAvailable        Poll = 0x1    // chance to try [File.Read]
+	PollHasPriority             Poll = 0x2    // priority has been passed to the file.
+	PollHasWriteAvailable       Poll = 0x4    // chance to try [File.Write]
+	PollHasPeerFinishedWriting  Poll = 0x2000 // remote socket peer shutdown write side.
+	PollHasPeerConnectionClosed Poll = 0x10   // remote socket peer closed connection.
+	PollHasError                Poll = 0x8    // only available in [FileToPoll.Result]
+	PollHasInvalidRequest       Poll = 0x20   // only available in [FileToPoll.Result]
+)
+
+var pollNames = []flagName[Poll]{
+	{"PollHasReadAvailable", PollHasReadAvailable},
+	{"PollHasPriority", PollHasPriority},
+	{"PollHasWriteAvailable", PollHasWriteAvailable},
+	{"PollHasPeerFinishedWriting", PollHasPeerFinishedWriting},
+	{"PollHasPeerConnectionClosed", PollHasPeerConnectionClosed},
+	{"PollHasError", PollHasError},
+	{"PollHasInvalidRequest", PollHasInvalidRequest},
+}
+
+func (v Poll) String() string { return formatFlags(v, "0", pollNames) }
+
+// ParsePoll parses names of [Poll] separated by '|', as formatted by [Poll.String].
+func ParsePoll(s string) (Poll, error) { return parseFlags("Poll", s, pollNames) }
+
+func (v Poll) MarshalText() ([]byte, error) { return []byte(v.String()), nil }
+
+func (v *Poll) UnmarshalText(text []byte) (err error) {
+	*v, err = ParsePoll(string(text))
+	return err
+}
+
+// Remap flags are used by [API.Remap].
+type Remap int
+
+const (
+	RemapMayMove      Remap = 0x1 // move the mapping to a new address, if it cannot be resized in place.
+	RemapExactAddress Remap = 0x2 // move the mapping to the given new address, requires [RemapMayMove].
+	RemapDoNotUnmap   Remap = 0x4 // keep the old mapping, with its pages moved to the new one, requires [RemapMayMove].
+)
+
+var remapNames = []flagName[Remap]{
+	{"RemapMayMove", RemapMayMove},
+	{"RemapExactAddress", RemapExactAddress},
+	{"RemapDoNotUnmap", RemapDoNotUnmap},
+}
+
+func (v Remap) String() string { return formatFlags(v, "0", remapNames) }
+
+// ParseRemap parses names of [Remap] separated by '|', as formatted by [Remap.String].
+func ParseRemap(s string) (Remap, error) { return parseFlags("Remap", s, remapNames) }
+
+func (v Remap) MarshalText() ([]byte, error) { return []byte(v.String()), nil }
+
+func (v *Remap) UnmarshalText(text []byte) (err error) {
+	*v, err = ParseRemap(string(text))
+	return err
+}
+
+// MemoryAdvice is used by [API.AdviseMemory] to describe how memory will be used.
+type MemoryAdvice int
+
+const (
+	MemoryAccessNormally     MemoryAdvice = 0  // no special treatment.
+	MemoryAccessRandomly     MemoryAdvice = 1  // expect random page references, read ahead less.
+	MemoryAccessSequentially MemoryAdvice = 2  // expect sequential page references, read ahead more.
+	MemoryWillBeNeeded       MemoryAdvice = 3  // expect access in the near future, read ahead now.
+	MemoryNotNeeded          MemoryAdvice = 4  // free the pages now, private anonymous memory reads as zero afterwards.
+	MemoryFree               MemoryAdvice = 8  // free the pages lazily, under memory pressure, unless they are written to first.
+	MemoryRemove             MemoryAdvice = 9  // free the pages and their backing store, for shared mappings.
+	MemoryDoNotFork          MemoryAdvice = 10 // do not make the pages available to child processes.
+	MemoryDoFork             MemoryAdvice = 11 // undo [MemoryDoNotFork].
+	MemoryMergeable          MemoryAdvice = 12 // enable merging of identical pages.
+	MemoryUnmergeable        MemoryAdvice = 13 // undo [MemoryMergeable].
+	MemoryHugePages          MemoryAdvice = 14 // enable transparent huge pages.
+	MemoryNoHugePages        MemoryAdvice = 15 // disable transparent huge pages.
+	MemoryDoNotDump          MemoryAdvice = 16 // exclude the pages from core dumps.
+	MemoryDoDump             MemoryAdvice = 17 // undo [MemoryDoNotDump].
+	MemoryCold               MemoryAdvice = 20 // deactivate the pages, so that they are reclaimed first.
+	MemoryPageOut            MemoryAdvice = 21 // reclaim the pages now.
+	MemoryPopulateRead       MemoryAdvice = 22 // prefault the page tables for reading.
+	MemoryPopulateWrite      MemoryAdvice = 23 // prefault the page tables for writing.
+	MemoryCollapse           MemoryAdvice = 25 // collapse the pages into transparent huge pages now.
+)
+
+var memoryAdviceNames = []flagName[MemoryAdvice]{
+	{"MemoryAccessNormally", MemoryAccessNormally},
+	{"MemoryAccessRandomly", MemoryAccessRandomly},
+	{"MemoryAccessSequentially", MemoryAccessSequentially},
+	{"MemoryWillBeNeeded", MemoryWillBeNeeded},
+	{"MemoryNotNeeded", MemoryNotNeeded},
+	{"MemoryFree", MemoryFree},
+	{"MemoryRemove", MemoryRemove},
+	{"MemoryDoNotFork", MemoryDoNotFork},
+	{"MemoryDoFork", MemoryDoFork},
+	{"MemoryMergeable", MemoryMergeable},
+	{"MemoryUnmergeable", MemoryUnmergeable},
+	{"MemoryHugePages", MemoryHugePages},
+	{"MemoryNoHugePages", MemoryNoHugePages},
+	{"MemoryDoNotDump", MemoryDoNotDump},
+	{"MemoryDoDump", MemoryDoDump},
+	{"MemoryCold", MemoryCold},
+	{"MemoryPageOut", MemoryPageOut},
+	{"MemoryPopulateRead", MemoryPopulateRead},
+	{"MemoryPopulateWrite", MemoryPopulateWrite},
+	{"MemoryCollapse", MemoryCollapse},
+}
+
+func (v MemoryAdvice) String() string { return formatEnum(v, memoryAdviceNames) }
+
+// ParseMemoryAdvice parses the name of a [MemoryAdvice], as formatted by [MemoryAdvice.String].
+func ParseMemoryAdvice(s string) (MemoryAdvice, error) {
+	return parseEnum("MemoryAdvice", s, memoryAdviceNames)
+}
+
+func (v MemoryAdvice) MarshalText() ([]byte, error) { return []byte(v.String()), nil }
+
+func (v *MemoryAdvice) UnmarshalText(text []byte) (err error) {
+	*v, err = ParseMemoryAdvice(string(text))
+	return err
+}
+
+// MemoryLock flags are used by [API.LockMemory].
+type MemoryLock int
+
+const (
+	MemoryLockOnFault MemoryLock = 0x1 // lock pages as they are faulted in, instead of populating them now.
+)
+
+var memoryLockNames = []flagName[MemoryLock]{
+	{"MemoryLockOnFault", MemoryLockOnFault},
+}
+
+func (v MemoryLock) String() string { return formatFlags(v, "0", memoryLockNames) }
+
+// ParseMemoryLock parses names of [MemoryLock] separated by '|', as formatted by [MemoryLock.String].
+func ParseMemoryLock(s string) (MemoryLock, error) {
+	return parseFlags("MemoryLock", s, memoryLockNames)
+}
+
+func (v MemoryLock) MarshalText() ([]byte, error) { return []byte(v.String()), nil }
+
+func (v *MemoryLock) UnmarshalText(text []byte) (err error) {
+	*v, err = ParseMemoryLock(string(text))
+	return err
+}
+
+// MemorySync flags are used by [API.SyncMemory].
+type MemorySync int
+
+const (
+	MemorySyncAsynchronously MemorySync = 0x1 // schedule the write back and return immediately.
+	MemorySyncInvalidate     MemorySync = 0x2 // invalidate other mappings of the file, so that they see the written data.
+	MemorySyncSynchronously  MemorySync = 0x4 // write back and wait for it to complete.
+)
+
+var memorySyncNames = []flagName[MemorySync]{
+	{"MemorySyncAsynchronously", MemorySyncAsynchronously},
+	{"MemorySyncInvalidate", MemorySyncInvalidate},
+	{"MemorySyncSynchronously", MemorySyncSynchronously},
+}
+
+func (v MemorySync) String() string { return formatFlags(v, "0", memorySyncNames) }
+
+// ParseMemorySync parses names of [MemorySync] separated by '|', as formatted by [MemorySync.String].
+func ParseMemorySync(s string) (MemorySync, error) {
+	return parseFlags("MemorySync", s, memorySyncNames)
+}
+
+func (v MemorySync) MarshalText() ([]byte, error) { return []byte(v.String()), nil }
+
+func (v *MemorySync) UnmarshalText(text []byte) (err error) {
+	*v, err = ParseMemorySync(string(text))
+	return err
+}
+
+// MemoryFileFlags are used by [API.CreateMemoryFile].
+type MemoryFileFlags int
+
+const (
+	MemoryFileCloseOnExecute MemoryFileFlags = 0x1 // close the file automatically on [Kernel.Execute].
+	MemoryFileAllowSealing   MemoryFileFlags = 0x2 // allow seals to be added to the file.
+	MemoryFileHugeTables     MemoryFileFlags = 0x4 // back the file with huge pages.
+)
+
+var memoryFileFlagsNames = []flagName[MemoryFileFlags]{
+	{"MemoryFileCloseOnExecute", MemoryFileCloseOnExecute},
+	{"MemoryFileAllowSealing", MemoryFileAllowSealing},
+	{"MemoryFileHugeTables", MemoryFileHugeTables},
+}
+
+func (v MemoryFileFlags) String() string { return formatFlags(v, "0", memoryFileFlagsNames) }
+
+// ParseMemoryFileFlags parses names of [MemoryFileFlags] separated by '|', as formatted by [MemoryFileFlags.String].
+func ParseMemoryFileFlags(s string) (MemoryFileFlags, error) {
+	return parseFlags("MemoryFileFlags", s, memoryFileFlagsNames)
+}
+
+func (v MemoryFileFlags) MarshalText() ([]byte, error) { return []byte(v.String()), nil }
+
+func (v *MemoryFileFlags) UnmarshalText(text []byte) (err error) {
+	*v, err = ParseMemoryFileFlags(string(text))
+	return err
+}
+
+// Clock is used by [API.ClockTime], [API.ClockResolution] and [API.Sleep] to select the clock to measure time with.
+type Clock int
+
+const (
+	ClockRealtime    Clock = 0  // wall-clock time since the Unix epoch, which can jump when it is set.
+	ClockMonotonic   Clock = 1  // time since an unspecified point, which never jumps, but does not advance while the system is suspended.
+	ClockProcessTime Clock = 2  // CPU time consumed by all of the threads of the process.
+	ClockThreadTime  Clock = 3  // CPU time consumed by the calling thread.
+	ClockBoottime    Clock = 7  // [ClockMonotonic] that also advances while the system is suspended.
+	ClockAtomicTime  Clock = 11 // International Atomic Time, [ClockRealtime] without leap seconds.
+)
+
+var clockNames = []flagName[Clock]{
+	{"ClockRealtime", ClockRealtime},
+	{"ClockMonotonic", ClockMonotonic},
+	{"ClockProcessTime", ClockProcessTime},
+	{"ClockThreadTime", ClockThreadTime},
+	{"ClockBoottime", ClockBoottime},
+	{"ClockAtomicTime", ClockAtomicTime},
+}
+
+func (v Clock) String() string { return formatEnum(v, clockNames) }
+
+// ParseClock parses the name of a [Clock], as formatted by [Clock.String].
+func ParseClock(s string) (Clock, error) { return parseEnum("Clock", s, clockNames) }
+
+func (v Clock) MarshalText() ([]byte, error) { return []byte(v.String()), nil }
+
+func (v *Clock) UnmarshalText(text []byte) (err error) {
+	*v, err = ParseClock(string(text))
+	return err
+}
+
+// Sleep flags are used by [API.Sleep].
+type Sleep int
+
+const (
+	SleepUntil Sleep = 0x1 // sleep until the clock reaches the given time, instead of for the given duration.
+)
+
+var sleepNames = []flagName[Sleep]{
+	{"SleepUntil", SleepUntil},
+}
+
+func (v Sleep) String() string { return formatFlags(v, "0", sleepNames) }
+
+// ParseSleep parses names of [Sleep] separated by '|', as formatted by [Sleep.String].
+func ParseSleep(s string) (Sleep, error) { return parseFlags("Sleep", s, sleepNames) }
+
+func (v Sleep) MarshalText() ([]byte, error) { return []byte(v.String()), nil }
+
+func (v *Sleep) UnmarshalText(text []byte) (err error) {
+	*v, err = ParseSleep(string(text))
+	return err
+}
+
+// FutexFlags are used by [API.WaitFutex] and the other futex operations.
+type FutexFlags int
+
+const (
+	FutexPrivate       FutexFlags = 0x80  // the futex is only used by this process, which is faster than sharing it with others.
+	FutexRealtimeClock FutexFlags = 0x100 // measure absolute deadlines against the realtime clock, instead of the monotonic clock.
+)
+
+var futexFlagsNames = []flagName[FutexFlags]{
+	{"FutexPrivate", FutexPrivate},
+	{"FutexRealtimeClock", FutexRealtimeClock},
+}
+
+func (v FutexFlags) String() string { return formatFlags(v, "0", futexFlagsNames) }
+
+// ParseFutexFlags parses names of [FutexFlags] separated by '|', as formatted by [FutexFlags.String].
+func ParseFutexFlags(s string) (FutexFlags, error) {
+	return parseFlags("FutexFlags", s, futexFlagsNames)
+}
+
+func (v FutexFlags) MarshalText() ([]byte, error) { return []byte(v.String()), nil }
+
+func (v *FutexFlags) UnmarshalText(text []byte) (err error) {
+	*v, err = ParseFutexFlags(string(text))
+	return err
+}
+
+// Seek is used for [API.Seek] to specify where and whence to seek.
+type Seek int
+
+const (
+	SeekRelativeToStart Seek = 0 // seek relative to the start of the file.
+	SeekRelative        Seek = 1 // seek relative to the current offset of the file.
+	SeekRelativeToEnd   Seek = 2 // seek relative to the end of the file.
+	SeekHole            Seek = 4 // seek to the next hole greater than or equal to the given offset.
+	SeekData            Seek = 3 // seek to the next data greater than or equal to the given offset.
+)
+
+var seekNames = []flagName[Seek]{
+	{"SeekRelativeToStart", SeekRelativeToStart},
+	{"SeekRelative", SeekRelative},
+	{"SeekRelativeToEnd", SeekRelativeToEnd},
+	{"SeekHole", SeekHole},
+	{"SeekData", SeekData},
+}
+
+func (v Seek) String() string { return formatEnum(v, seekNames) }
+
+// ParseSeek parses the name of a [Seek], as formatted by [Seek.String].
+func ParseSeek(s string) (Seek, error) { return parseEnum("Seek", s, seekNames) }
+
+func (v Seek) MarshalText() ([]byte, error) { return []byte(v.String()), nil }
+
+func (v *Seek) UnmarshalText(text []byte) (err error) {
+	*v, err = ParseSeek(string(text))
+	return err
+}
+
+// Rename flags are used by [API.Rename].
+type Rename int
+
+const (
+	RenameDoNotReplace Rename = 0x1 // fail if the destination already exists.
+	RenameExchange     Rename = 0x2 // atomically exchange the source and the destination, which must both exist.
+	RenameWhiteout     Rename = 0x4 // leave a whiteout in place of the source, for overlay file systems.
+)
+
+var renameNames = []flagName[Rename]{
+	{"RenameDoNotReplace", RenameDoNotReplace},
+	{"RenameExchange", RenameExchange},
+	{"RenameWhiteout", RenameWhiteout},
+}
+
+func (v Rename) String() string { return formatFlags(v, "0", renameNames) }
+
+// ParseRename parses names of [Rename] separated by '|', as formatted by [Rename.String].
+func ParseRename(s string) (Rename, error) { return parseFlags("Rename", s, renameNames) }
+
+func (v Rename) MarshalText() ([]byte, error) { return []byte(v.String()), nil }
+
+func (v *Rename) UnmarshalText(text []byte) (err error) {
+	*v, err = ParseRename(string(text))
+	return err
+}
+
+// EventFlags are used by [API.CreateEvent].
+type EventFlags int
+
+const (
+	EventCloseOnExecute EventFlags = 0x80000 // close the event automatically on [Kernel.Execute].
+	EventNonBlocking    EventFlags = 0x800   // return "resource temporarily unavailable" if a read/write would block.
+	EventSemaphore      EventFlags = 0x1     // reads decrement the counter by one, instead of resetting it to zero.
+)
+
+var eventFlagsNames = []flagName[EventFlags]{
+	{"EventCloseOnExecute", EventCloseOnExecute},
+	{"EventNonBlocking", EventNonBlocking},
+	{"EventSemaphore", EventSemaphore},
+}
+
+func (v EventFlags) String() string { return formatFlags(v, "0", eventFlagsNames) }
+
+// ParseEventFlags parses names of [EventFlags] separated by '|', as formatted by [EventFlags.String].
+func ParseEventFlags(s string) (EventFlags, error) {
+	return parseFlags("EventFlags", s, eventFlagsNames)
+}
+
+func (v EventFlags) MarshalText() ([]byte, error) { return []byte(v.String()), nil }
+
+func (v *EventFlags) UnmarshalText(text []byte) (err error) {
+	*v, err = ParseEventFlags(string(text))
+	return err
+}
+
+// FileCreationFlags affect the semantics of the [API.Open] operation.
+type FileCreationFlags int
+
+const (
+	FileCloseOnExecute   FileCreationFlags = 0x80000 // close the file automatically on [Kernel.Execute].
+	FileCreateIfNeeded   FileCreationFlags = 0x40    // create the file if it does not exist.
+	FileAssertCreation   FileCreationFlags = 0x80    // fail to open if the file already exists.
+	FileIsNotTheTerminal FileCreationFlags = 0x100   // if the pathname is a terminal, it shouldn't become the controlling terminal for the process.
+	FileTruncatedToZero  FileCreationFlags = 0x200   // resets the file to length 0, writes will overwrite any existing content.
+)
+
+var fileCreationFlagsNames = []flagName[FileCreationFlags]{
+	{"FileTemporaryInside", FileTemporaryInside},
+	{"FileCloseOnExecute", FileCloseOnExecute},
+	{"FileCreateIfNeeded", FileCreateIfNeeded},
+	{"FileAssertDirectory", FileAssertDirectory},
+	{"FileAssertCreation", FileAssertCreation},
+	{"FileIsNotTheTerminal", FileIsNotTheTerminal},
+	{"FileTrapSymbolicLink", FileTrapSymbolicLink},
+	{"FileTruncatedToZero", FileTruncatedToZero},
+}
+
+func (v FileCreationFlags) String() string { return formatFlags(v, "0", fileCreationFlagsNames) }
+
+// ParseFileCreationFlags parses names of [FileCreationFlags] separated by '|', as formatted by [FileCreationFlags.String].
+func ParseFileCreationFlags(s string) (FileCreationFlags, error) {
+	return parseFlags("FileCreationFlags", s, fileCreationFlagsNames)
+}
+
+func (v FileCreationFlags) MarshalText() ([]byte, error) { return []byte(v.String()), nil }
+
+func (v *FileCreationFlags) UnmarshalText(text []byte) (err error) {
+	*v, err = ParseFileCreationFlags(string(text))
+	return err
+}
+
+// FileStatusFlags affect the semantics of subsequent I/O operations. These can be retrieved and (in some cases) modified;
+// see [File.Status] for details.
+type FileStatusFlags int
+
+const (
+	FileAppend                FileStatusFlags = 0x400    // append data to the end of the file when writing.
+	FileAsync                 FileStatusFlags = 0x2000   // emit [SignalIO] whenever input or output becomes available.
+	FileSyncData              FileStatusFlags = 0x1000   // all [File.Write] operations are automatically followed by a [File.SyncData].
+	FileDoNotUpdateAccessTime FileStatusFlags = 0x40000  // request that the access time of the file is not updated on [File.Read]
+	FileNonBlocking           FileStatusFlags = 0x800    // return "resource temporarily unavailable" if a read/write would block
+	FilePath                  FileStatusFlags = 0x200000 // file is opened as a reference-only, no read/write operations are allowed.
+	FileSync                  FileStatusFlags = 0x101000 // all [File.Write] operations are automatically followed by a [File.Sync].
+)
+
+var fileStatusFlagsNames = []flagName[FileStatusFlags]{
+	{"FileSync", FileSync},
+	{"FileAppend", FileAppend},
+	{"FileAsync", FileAsync},
+	{"FileDirect", FileDirect},
+	{"FileSyncData", FileSyncData},
+	{"FileDoNotUpdateAccessTime", FileDoNotUpdateAccessTime},
+	{"FileNonBlocking", FileNonBlocking},
+	{"FilePath", FilePath},
+}
+
+func (v FileStatusFlags) String() string { return formatFlags(v, "0", fileStatusFlagsNames) }
+
+// ParseFileStatusFlags parses names of [FileStatusFlags] separated by '|', as formatted by [FileStatusFlags.String].
+func ParseFileStatusFlags(s string) (FileStatusFlags, error) {
+	return parseFlags("FileStatusFlags", s, fileStatusFlagsNames)
+}
+
+func (v FileStatusFlags) MarshalText() ([]byte, error) { return []byte(v.String()), nil }
+
+func (v *FileStatusFlags) UnmarshalText(text []byte) (err error) {
+	*v, err = ParseFileStatusFlags(string(text))
+	return err
+}
+
+// FilePermissions mode bits.
+type FilePermissions uint32
+
+const (
+	FileReadableByUser          FilePermissions = 0400  // file is readable by its owner
+	FileReadableByGroup         FilePermissions = 040   // file is readable by its group
+	FileReadableByOthers        FilePermissions = 04    // file is readable by others
+	FileWritableByUser          FilePermissions = 0200  // file is writable by its owner
+	FileWritableByGroup         FilePermissions = 020   // file is writable by its group
+	FileWritableByOthers        FilePermissions = 02    // file is writable by others
+	FileExecutableByUser        FilePermissions = 0100  // file is executable by its owner
+	FileExecutableByGroup       FilePermissions = 010   // file is executable by its group
+	FileExecutableByOthers      FilePermissions = 01    // file is executable by others
+	FileExecutesAsOwner         FilePermissions = 04000 // file will be executed as if it were executed by the owner of the file
+	FileExecutesAsGroup         FilePermissions = 02000 // file will be executed as if it were executed by the group of the file
+	FilesInheritGroup           FilePermissions = 02000 // files created in this directory inherit their group ID from the directory
+	FilesLockedToOwner          FilePermissions = 01000 // files in this directory can only be renamed or deleted by owners.
+	DirectorySearchableByUser   FilePermissions = 0100  // directory is searchable by its owner
+	DirectorySearchableByGroup  FilePermissions = 010   // directory is searchable by its group
+	DirectorySearchableByOthers FilePermissions = 01    // directory is searchable by others
+)
+
+// FileType is the type of a file, as recorded in the type bits of [FileHeader.Permissions].
+type FileType uint32
+
+const (
+	FileTypeRegular         FileType = 0100000 // regular file.
+	FileTypeDirectory       FileType = 040000  // directory.
+	FileTypeSymbolicLink    FileType = 0120000 // symbolic link.
+	FileTypeSocket          FileType = 0140000 // unix domain socket.
+	FileTypeNamedPipe       FileType = 010000  // named pipe (FIFO).
+	FileTypeCharacterDevice FileType = 020000  // character device.
+	FileTypeBlockDevice     FileType = 060000  // block device.
+)
+
+var fileTypeNames = []flagName[FileType]{
+	{"FileTypeRegular", FileTypeRegular},
+	{"FileTypeDirectory", FileTypeDirectory},
+	{"FileTypeSymbolicLink", FileTypeSymbolicLink},
+	{"FileTypeSocket", FileTypeSocket},
+	{"FileTypeNamedPipe", FileTypeNamedPipe},
+	{"FileTypeCharacterDevice", FileTypeCharacterDevice},
+	{"FileTypeBlockDevice", FileTypeBlockDevice},
+}
+
+func (v FileType) String() string { return formatEnum(v, fileTypeNames) }
+
+// ParseFileType parses the name of a [FileType], as formatted by [FileType.String].
+func ParseFileType(s string) (FileType, error) { return parseEnum("FileType", s, fileTypeNames) }
+
+func (v FileType) MarshalText() ([]byte, error) { return []byte(v.String()), nil }
+
+func (v *FileType) UnmarshalText(text []byte) (err error) {
+	*v, err = ParseFileType(string(text))
+	return err
+}
+
+// FileAccessMode request opening the file read-only, write-only, or read/write, respectively.
+type FileAccessMode int
+
+const (
+	FileAccessReadOnly  FileAccessMode = 0 // enable reads
+	FileAccessWriteOnly FileAccessMode = 1 // enable writes
+	FileAccessReadWrite FileAccessMode = 2 // enable both reads and writes
+)
+
+var fileAccessModeNames = []flagName[FileAccessMode]{
+	{"FileAccessReadOnly", FileAccessReadOnly},
+	{"FileAccessWriteOnly", FileAccessWriteOnly},
+	{"FileAccessReadWrite", FileAccessReadWrite},
+}
+
+func (v FileAccessMode) String() string { return formatEnum(v, fileAccessModeNames) }
+
+// ParseFileAccessMode parses the name of a [FileAccessMode], as formatted by [FileAccessMode.String].
+func ParseFileAccessMode(s string) (FileAccessMode, error) {
+	return parseEnum("FileAccessMode", s, fileAccessModeNames)
+}
+
+func (v FileAccessMode) MarshalText() ([]byte, error) { return []byte(v.String()), nil }
+
+func (v *FileAccessMode) UnmarshalText(text []byte) (err error) {
+	*v, err = ParseFileAccessMode(string(text))
+	return err
+}
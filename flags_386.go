@@ -0,0 +1,17 @@
+// Code generated by "go run ./internal/flagsgen"; DO NOT EDIT.
+
+package linux
+
+const (
+	Map32Bit Map = 0x40 // allocate memory in the first 4GB of the address space, zero where unsupported.
+)
+
+const (
+	FileAssertDirectory  FileCreationFlags = 0x10000  // fail to open if the path is not a directory.
+	FileTrapSymbolicLink FileCreationFlags = 0x20000  // if the trailing component is a symbolic link, don't follow it, open it directly.
+	FileTemporaryInside  FileCreationFlags = 0x410000 // creates an unnamed temporary file inside the provided directory
+)
+
+const (
+	FileDirect FileStatusFlags = 0x4000 // avoid cache where possible and use underlying hardware directly
+)
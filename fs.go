@@ -0,0 +1,197 @@
+package linux
+
+import (
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+)
+
+// FileSystem returns an [fs.FS] for the tree of files rooted at the given
+// directory, that makes its calls through api, so that code built on [io/fs]
+// can run against any [API]. The file system implements [fs.ReadDirFS],
+// [fs.StatFS], [fs.ReadFileFS] and [fs.SubFS], and its files are [File] values
+// that implement [fs.ReadDirFile] and [io.Seeker]. Like [os.DirFS], symbolic
+// links are followed, even when they point outside of the root.
+func FileSystem(api *API, root Path) fs.FS {
+	return &fileSystem{api: api, root: root}
+}
+
+type fileSystem struct {
+	api  *API
+	root Path
+}
+
+// path of name within the root, or an error if name is not a valid path.
+func (fsys *fileSystem) path(op, name string) (Path, error) {
+	if !fs.ValidPath(name) {
+		return "", &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	return Path(path.Join(string(fsys.root), name)), nil
+}
+
+func (fsys *fileSystem) Open(name string) (fs.File, error) {
+	full, err := fsys.path("open", name)
+	if err != nil {
+		return nil, err
+	}
+	file, err := fsys.api.Open(full, FileAccessReadOnly, FileCloseOnExecute, 0, 0)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	return &fileSystemFile{File: &File{Linux: file.Linux, Descriptor: file.Descriptor}, name: name, path: full}, nil
+}
+
+func (fsys *fileSystem) Stat(name string) (fs.FileInfo, error) {
+	full, err := fsys.path("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	header, err := fsys.api.Stat(full)
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+	}
+	return header.FileInfo(path.Base(name)), nil
+}
+
+func (fsys *fileSystem) ReadDir(name string) ([]fs.DirEntry, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	entries, err := file.(fs.ReadDirFile).ReadDir(-1)
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int { return strings.Compare(a.Name(), b.Name()) })
+	return entries, err
+}
+
+func (fsys *fileSystem) ReadFile(name string) ([]byte, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var size int64
+	if info, err := file.Stat(); err == nil {
+		size = info.Size()
+	}
+	var data = make([]byte, 0, size+1)
+	for {
+		if len(data) == cap(data) {
+			data = append(data, 0)[:len(data)]
+		}
+		n, err := file.Read(data[len(data):cap(data)])
+		data = data[:len(data)+n]
+		if err == io.EOF {
+			return data, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (fsys *fileSystem) Sub(dir string) (fs.FS, error) {
+	full, err := fsys.path("sub", dir)
+	if err != nil {
+		return nil, err
+	}
+	return &fileSystem{api: fsys.api, root: full}, nil
+}
+
+// fileSystemFile is a [File] opened by a [FileSystem].
+type fileSystemFile struct {
+	*File
+	name string // name of the file within the [FileSystem].
+	path Path   // path of the file, to stat the entries of a directory.
+
+	buf     []byte           // buffer for [File.ReadDirectory].
+	entries []DirectoryEntry // entries read but not yet returned by ReadDir.
+	eof     bool             // no more entries to read.
+}
+
+func (f *fileSystemFile) Stat() (fs.FileInfo, error) {
+	header, err := f.File.Stat()
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: f.name, Err: err}
+	}
+	return header.FileInfo(path.Base(f.name)), nil
+}
+
+func (f *fileSystemFile) Read(p []byte) (int, error) {
+	n, err := f.File.Read(p)
+	if err != nil {
+		return max(n, 0), &fs.PathError{Op: "read", Path: f.name, Err: err}
+	}
+	if n == 0 && len(p) > 0 {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+func (f *fileSystemFile) Close() error {
+	if err := f.File.Close(); err != nil {
+		return &fs.PathError{Op: "close", Path: f.name, Err: err}
+	}
+	return nil
+}
+
+func (f *fileSystemFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	var entries []fs.DirEntry
+	for n <= 0 || len(entries) < n {
+		if len(f.entries) == 0 && !f.eof {
+			if f.buf == nil {
+				f.buf = make([]byte, 8192)
+			}
+			read, err := f.File.ReadDirectory(f.buf)
+			if err != nil {
+				return entries, &fs.PathError{Op: "readdir", Path: f.name, Err: err}
+			}
+			f.entries, f.eof = read, len(read) == 0
+		}
+		if len(f.entries) == 0 {
+			break
+		}
+		var entry = f.entries[0]
+		f.entries = f.entries[1:]
+		if entry.Name == "." || entry.Name == ".." {
+			continue
+		}
+		entries = append(entries, &fileSystemEntry{api: f.Linux, path: Path(path.Join(string(f.path), string(entry.Name))), entry: entry})
+	}
+	if n > 0 && len(entries) == 0 {
+		return nil, io.EOF
+	}
+	return entries, nil
+}
+
+// fileSystemEntry implements [fs.DirEntry] for a [DirectoryEntry].
+type fileSystemEntry struct {
+	api   *API
+	path  Path
+	entry DirectoryEntry
+}
+
+func (e *fileSystemEntry) Name() string { return string(e.entry.Name) }
+
+func (e *fileSystemEntry) IsDir() bool { return e.Type().IsDir() }
+
+func (e *fileSystemEntry) Type() fs.FileMode {
+	if e.entry.Type == 0 {
+		if info, err := e.Info(); err == nil {
+			return info.Mode().Type()
+		}
+	}
+	return FilePermissions(e.entry.Type).AsFileMode().Type()
+}
+
+func (e *fileSystemEntry) Info() (fs.FileInfo, error) {
+	header, err := e.api.StatLink(e.path)
+	if err != nil {
+		return nil, &fs.PathError{Op: "lstat", Path: string(e.entry.Name), Err: err}
+	}
+	return header.FileInfo(string(e.entry.Name)), nil
+}
+
+func (e *fileSystemEntry) String() string { return fs.FormatDirEntry(e) }
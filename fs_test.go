@@ -0,0 +1,122 @@
+package linux_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"verbose.style/linux"
+)
+
+func TestFileSystem(t *testing.T) {
+	var dir = t.TempDir()
+	for name, data := range map[string]string{
+		"a.txt":          "a",
+		"b/c.txt":        "hello world",
+		"b/d/e.txt":      "",
+		"b/d/f/g/h.json": "{}",
+	} {
+		var path = filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var fsys = linux.FileSystem(linux.Native(), linux.Path(dir))
+	if err := fstest.TestFS(fsys, "a.txt", "b/c.txt", "b/d/e.txt", "b/d/f/g/h.json"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := fs.ReadFile(fsys, "b/c.txt")
+	if err != nil || string(data) != "hello world" {
+		t.Fatal(string(data), err)
+	}
+	sub, err := fs.Sub(fsys, "b/d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fstest.TestFS(sub, "e.txt", "f/g/h.json"); err != nil {
+		t.Fatal(err)
+	}
+	file, err := sub.Open("e.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := file.(fs.ReadDirFile); !ok {
+		t.Fatalf("%T is not an fs.ReadDirFile", file)
+	}
+	file.Close()
+	if _, err := fsys.Open("missing"); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatal(err)
+	}
+	if _, err := fsys.Open("../a.txt"); !errors.Is(err, fs.ErrInvalid) {
+		t.Fatal(err)
+	}
+}
+
+func TestFileSystemMemory(t *testing.T) {
+	var api = linux.Memory()
+	for name, data := range map[linux.Path]string{"a.txt": "a", "b.txt": "bb", "c.txt": ""} {
+		file, err := api.Open("/"+name, linux.FileAccessWriteOnly, linux.FileCreateIfNeeded, 0, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := file.Write([]byte(data)); err != nil && data != "" {
+			t.Fatal(err)
+		}
+		file.Close()
+	}
+	var fsys = linux.FileSystem(api, "/")
+	if err := fstest.TestFS(fsys, "a.txt", "b.txt", "c.txt"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadDirectory(t *testing.T) {
+	var dir = t.TempDir()
+	var native = linux.Native()
+	var memory = linux.Memory()
+	for _, name := range []string{"a", "bb", "a-much-longer-name-than-the-rest"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+		file, err := memory.Open(linux.Path("/"+name), linux.FileAccessWriteOnly, linux.FileCreateIfNeeded, 0, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		file.Close()
+	}
+	for _, test := range []struct {
+		api  *linux.API
+		path linux.Path
+	}{{native, linux.Path(dir)}, {memory, "/"}} {
+		file, err := test.api.Open(test.path, linux.FileAccessReadOnly, linux.FileAssertDirectory, 0, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := file.ReadDirectory(make([]byte, 8)); err != new(linux.ReadDirectoryError).Types().BufferTooSmall {
+			t.Fatal(err)
+		}
+		var names = make(map[linux.Path]linux.FileType)
+		for {
+			entries, err := file.ReadDirectory(make([]byte, 64))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) == 0 {
+				break
+			}
+			for _, entry := range entries {
+				names[entry.Name] = entry.Type
+			}
+		}
+		file.Close()
+		if len(names) != 5 || names["."] != linux.FileTypeDirectory || names["a-much-longer-name-than-the-rest"] != linux.FileTypeRegular {
+			t.Fatal(names)
+		}
+	}
+}
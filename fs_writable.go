@@ -0,0 +1,314 @@
+package linux
+
+import (
+	"errors"
+	"io/fs"
+	"math/rand/v2"
+	"path"
+	"strconv"
+)
+
+// WritableFS is an [fs.FS] that can also modify its files, implemented only in
+// terms of [API] functions, see [WritableFileSystem].
+type WritableFS interface {
+	fs.FS
+
+	// Create the file, or truncate it if it exists, and open it for reading
+	// and writing.
+	Create(name string, perm FilePermissions) (*File, error)
+	// MakeDirectoryAll creates the directory along with any missing parents,
+	// it does nothing if the directory already exists.
+	MakeDirectoryAll(name string, perm FilePermissions) error
+	// RemoveAll removes the file, or the directory along with everything it
+	// contains, it does nothing if the file does not exist.
+	RemoveAll(name string) error
+	// Rename the file, replacing any file at the destination.
+	Rename(from, to string) error
+	// WriteFileAtomically replaces the contents of the file, such that the file
+	// either has its previous contents or data, even if the system crashes.
+	WriteFileAtomically(name string, data []byte, perm FilePermissions) error
+}
+
+// WritableFileSystem returns a [FileSystem] for the tree of files rooted at
+// the given directory, that can also modify them.
+func WritableFileSystem(api *API, root Path) WritableFS {
+	return &fileSystem{api: api, root: root}
+}
+
+func (fsys *fileSystem) Create(name string, perm FilePermissions) (*File, error) {
+	full, err := fsys.path("create", name)
+	if err != nil {
+		return nil, err
+	}
+	file, err := fsys.api.Open(full, FileAccessReadWrite, FileCreateIfNeeded|FileTruncatedToZero|FileCloseOnExecute, 0, perm)
+	if err != nil {
+		return nil, &fs.PathError{Op: "create", Path: name, Err: err}
+	}
+	return &File{Linux: file.Linux, Descriptor: file.Descriptor}, nil
+}
+
+func (fsys *fileSystem) MakeDirectoryAll(name string, perm FilePermissions) error {
+	full, err := fsys.path("mkdir", name)
+	if err != nil {
+		return err
+	}
+	return fsys.makeDirectoryAll(name, full, perm)
+}
+
+func (fsys *fileSystem) makeDirectoryAll(name string, full Path, perm FilePermissions) error {
+	if header, err := fsys.api.Stat(full); err == nil {
+		if header.IsDirectory() {
+			return nil
+		}
+		return &fs.PathError{Op: "mkdir", Path: name, Err: new(MakeDirectoryError).Types().NotDirectory}
+	}
+	if parent := path.Dir(name); parent != name {
+		if err := fsys.makeDirectoryAll(parent, Path(path.Dir(string(full))), perm); err != nil {
+			return err
+		}
+	}
+	if err := fsys.api.MakeDirectory(full, perm); err != nil {
+		if header, serr := fsys.api.Stat(full); serr == nil && header.IsDirectory() {
+			return nil
+		}
+		return &fs.PathError{Op: "mkdir", Path: name, Err: err}
+	}
+	return nil
+}
+
+func (fsys *fileSystem) RemoveAll(name string) error {
+	full, err := fsys.path("removeall", name)
+	if err != nil {
+		return err
+	}
+	if name == "." {
+		return &fs.PathError{Op: "removeall", Path: name, Err: fs.ErrInvalid}
+	}
+	return fsys.removeAll(name, full)
+}
+
+func (fsys *fileSystem) removeAll(name string, full Path) error {
+	header, err := fsys.api.StatLink(full)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return &fs.PathError{Op: "removeall", Path: name, Err: err}
+	}
+	if !header.IsDirectory() {
+		if err := fsys.api.RemoveFile(full); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return &fs.PathError{Op: "removeall", Path: name, Err: err}
+		}
+		return nil
+	}
+	dir, err := fsys.api.Open(full, FileAccessReadOnly, FileAssertDirectory|FileTrapSymbolicLink|FileCloseOnExecute, 0, 0)
+	if err != nil {
+		return &fs.PathError{Op: "removeall", Path: name, Err: err}
+	}
+	var children []Path
+	var buf = make([]byte, 8192)
+	for {
+		entries, err := dir.ReadDirectory(buf)
+		if err != nil {
+			dir.Close()
+			return &fs.PathError{Op: "removeall", Path: name, Err: err}
+		}
+		if len(entries) == 0 {
+			break
+		}
+		for _, entry := range entries {
+			if entry.Name != "." && entry.Name != ".." {
+				children = append(children, entry.Name)
+			}
+		}
+	}
+	dir.Close()
+	for _, child := range children {
+		if err := fsys.removeAll(path.Join(name, string(child)), Path(path.Join(string(full), string(child)))); err != nil {
+			return err
+		}
+	}
+	if err := fsys.api.RemoveDirectory(full); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return &fs.PathError{Op: "removeall", Path: name, Err: err}
+	}
+	return nil
+}
+
+func (fsys *fileSystem) Rename(from, to string) error {
+	src, err := fsys.path("rename", from)
+	if err != nil {
+		return err
+	}
+	dst, err := fsys.path("rename", to)
+	if err != nil {
+		return err
+	}
+	if err := fsys.api.Rename(src, dst, 0); err != nil {
+		return &fs.PathError{Op: "rename", Path: from, Err: err}
+	}
+	return nil
+}
+
+// WriteFileAtomically writes data to an unnamed file created with
+// [FileTemporaryInside], syncs it and links it into the directory under a
+// temporary name, which is then renamed over the file, before the directory
+// itself is synced. When the file system does not support unnamed files, a
+// named temporary file is used instead.
+func (fsys *fileSystem) WriteFileAtomically(name string, data []byte, perm FilePermissions) error {
+	full, err := fsys.path("writefile", name)
+	if err != nil {
+		return err
+	}
+	if err := fsys.writeFileAtomically(full, data, perm); err != nil {
+		return &fs.PathError{Op: "writefile", Path: name, Err: err}
+	}
+	return nil
+}
+
+func (fsys *fileSystem) writeFileAtomically(full Path, data []byte, perm FilePermissions) error {
+	var api = fsys.api
+	var dir = Path(path.Dir(string(full)))
+	var temporary = func() Path {
+		return Path(path.Join(string(dir), "."+path.Base(string(full))+"."+strconv.FormatUint(rand.Uint64(), 36)+".tmp"))
+	}
+	file, err := api.Open(dir, FileAccessWriteOnly, FileTemporaryInside|FileCloseOnExecute, 0, perm)
+	if err != nil {
+		var types = new(OpenError).Types()
+		if !errors.Is(err, types.Unsupported) && !errors.Is(err, types.Directory) && !errors.Is(err, types.Invalid) {
+			return err
+		}
+		return fsys.writeFileNamed(full, temporary, data, perm)
+	}
+	defer api.Close(file.Descriptor)
+	if err := writeAll(api, file.Descriptor, data); err != nil {
+		return err
+	}
+	if err := api.Sync(file.Descriptor); err != nil {
+		return err
+	}
+	var name = temporary()
+	for {
+		err := api.LinkFile(file.Descriptor, name)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, new(LinkError).Types().AlreadyExists) {
+			return err
+		}
+		name = temporary()
+	}
+	if err := api.Rename(name, full, 0); err != nil {
+		api.RemoveFile(name)
+		return err
+	}
+	return syncDirectory(api, dir)
+}
+
+// writeFileNamed writes data to a new temporary file, syncs it and renames it
+// over the file, removing it on failure.
+func (fsys *fileSystem) writeFileNamed(full Path, temporary func() Path, data []byte, perm FilePermissions) error {
+	var api = fsys.api
+	var name Path
+	var file File
+	for {
+		var err error
+		name = temporary()
+		file, err = api.Open(name, FileAccessWriteOnly, FileCreateIfNeeded|FileAssertCreation|FileCloseOnExecute, 0, perm)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, new(OpenError).Types().AlreadyExists) {
+			return err
+		}
+	}
+	var err = writeAll(api, file.Descriptor, data)
+	if err == nil {
+		err = api.Sync(file.Descriptor)
+	}
+	if cerr := api.Close(file.Descriptor); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = api.Rename(name, full, 0)
+	}
+	if err != nil {
+		api.RemoveFile(name)
+		return err
+	}
+	return syncDirectory(api, Path(path.Dir(string(full))))
+}
+
+func writeAll(api *API, fd FileDescriptor, data []byte) error {
+	for len(data) > 0 {
+		n, err := api.Write(fd, data)
+		if err != nil {
+			return err
+		}
+		data = data[n:]
+	}
+	return nil
+}
+
+// syncDirectory syncs the entries of the directory, so that a rename within it
+// is durable.
+func syncDirectory(api *API, dir Path) error {
+	file, err := api.Open(dir, FileAccessReadOnly, FileAssertDirectory|FileCloseOnExecute, 0, 0)
+	if err != nil {
+		return err
+	}
+	err = api.Sync(file.Descriptor)
+	if cerr := api.Close(file.Descriptor); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// CopyAll copies the tree of files at srcDir in src into dstDir in dst,
+// creating directories as needed and replacing existing files. Only regular
+// files and directories can be copied.
+func CopyAll(dst WritableFS, dstDir string, src fs.FS, srcDir string) error {
+	return fs.WalkDir(src, srcDir, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		var target = path.Join(dstDir, relative(srcDir, name))
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		var perm = FilePermissions(info.Mode().Perm())
+		switch {
+		case entry.IsDir():
+			return dst.MakeDirectoryAll(target, perm)
+		case !entry.Type().IsRegular():
+			return &fs.PathError{Op: "copy", Path: name, Err: fs.ErrInvalid}
+		}
+		data, err := fs.ReadFile(src, name)
+		if err != nil {
+			return err
+		}
+		file, err := dst.Create(target, perm)
+		if err != nil {
+			return err
+		}
+		err = writeAll(file.Linux, file.Descriptor, data)
+		if cerr := file.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return &fs.PathError{Op: "copy", Path: target, Err: err}
+		}
+		return nil
+	})
+}
+
+// relative returns name relative to dir, as walked by [fs.WalkDir].
+func relative(dir, name string) string {
+	switch {
+	case dir == ".":
+		return name
+	case name == dir:
+		return "."
+	}
+	return name[len(dir)+1:]
+}
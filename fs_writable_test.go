@@ -0,0 +1,81 @@
+package linux_test
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"verbose.style/linux"
+)
+
+func TestWritableFileSystem(t *testing.T) {
+	for name, fsys := range map[string]linux.WritableFS{
+		"Native": linux.WritableFileSystem(linux.Native(), linux.Path(t.TempDir())),
+		"Memory": linux.WritableFileSystem(linux.Memory(), "/"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			if err := fsys.MakeDirectoryAll("a/b/c", 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := fsys.MakeDirectoryAll("a/b", 0755); err != nil {
+				t.Fatal(err)
+			}
+			file, err := fsys.Create("a/b/c/file.txt", 0644)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := file.Write([]byte("hello")); err != nil {
+				t.Fatal(err)
+			}
+			if err := file.Sync(); err != nil {
+				t.Fatal(err)
+			}
+			file.Close()
+			if err := fsys.MakeDirectoryAll("a/b/c/file.txt", 0755); err == nil {
+				t.Fatal("created a directory over a file")
+			}
+			for _, data := range []string{"version: 1", "version: 2"} {
+				if err := fsys.WriteFileAtomically("a/config.yaml", []byte(data), 0600); err != nil {
+					t.Fatal(err)
+				}
+				got, err := fs.ReadFile(fsys, "a/config.yaml")
+				if err != nil || string(got) != data {
+					t.Fatal(string(got), err)
+				}
+			}
+			info, err := fs.Stat(fsys, "a/config.yaml")
+			if err != nil || info.Mode().Perm() != 0600 {
+				t.Fatal(info, err)
+			}
+			if err := fsys.Rename("a/b", "a/d"); err != nil {
+				t.Fatal(err)
+			}
+			if err := fstest.TestFS(fsys, "a/config.yaml", "a/d/c/file.txt"); err != nil {
+				t.Fatal(err)
+			}
+			entries, err := fs.ReadDir(fsys, "a")
+			if err != nil || len(entries) != 2 {
+				t.Fatal(entries, err)
+			}
+			if err := linux.CopyAll(fsys, "copy", fsys, "a"); err != nil {
+				t.Fatal(err)
+			}
+			if got, err := fs.ReadFile(fsys, "copy/d/c/file.txt"); err != nil || string(got) != "hello" {
+				t.Fatal(string(got), err)
+			}
+			if err := fsys.RemoveAll("a"); err != nil {
+				t.Fatal(err)
+			}
+			if err := fsys.RemoveAll("a"); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := fs.Stat(fsys, "a/d/c/file.txt"); !errors.Is(err, fs.ErrNotExist) {
+				t.Fatal(err)
+			}
+			if err := fstest.TestFS(fsys, "copy/config.yaml", "copy/d/c/file.txt"); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package linux_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"verbose.style/linux"
+)
+
+// wakeUntil calls wake until it reports n waiters, as they may not be waiting
+// yet.
+func wakeUntil(t *testing.T, n int, wake func() (int, error)) {
+	t.Helper()
+	for range 1000 {
+		woken, err := wake()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n -= woken; n <= 0 {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("%d waiters were not woken", n)
+}
+
+func TestFutex(t *testing.T) {
+	for name, api := range map[string]*linux.API{"Native": linux.Native(), "Memory": linux.Memory()} {
+		t.Run(name, func(t *testing.T) {
+			var futex, other uint32 = 1, 2
+			var timeout = linux.TimeFrom(time.Unix(0, int64(time.Millisecond)))
+			if err := api.WaitFutex(&futex, 0, nil, 0); !errors.Is(err, new(linux.FutexError).Types().WouldBlock) {
+				t.Fatalf("expected would block, got %v", err)
+			}
+			if err := api.WaitFutex(&futex, 1, &timeout, linux.FutexPrivate); !errors.Is(err, new(linux.FutexError).Types().TimedOut) {
+				t.Fatalf("expected timed out, got %v", err)
+			}
+			var past = linux.TimeFrom(time.Now().Add(-time.Second))
+			if err := api.WaitFutexBitset(&futex, 1, &past, 1, linux.FutexRealtimeClock); !errors.Is(err, new(linux.FutexError).Types().TimedOut) {
+				t.Fatalf("expected timed out, got %v", err)
+			}
+
+			var done = make(chan error, 2)
+			go func() { done <- api.WaitFutexBitset(&futex, 1, nil, 0b01, 0) }()
+			time.Sleep(10 * time.Millisecond)
+			if n, err := api.WakeFutexBitset(&futex, 1, 0b10, 0); n != 0 || err != nil {
+				t.Fatalf("woke %d waiters with a disjoint bitset, %v", n, err)
+			}
+			wakeUntil(t, 1, func() (int, error) { return api.WakeFutexBitset(&futex, 1, 0b11, 0) })
+			if err := <-done; err != nil {
+				t.Fatal(err)
+			}
+
+			for range 2 {
+				go func() { done <- api.WaitFutex(&futex, 1, nil, 0) }()
+			}
+			if _, err := api.RequeueFutex(&futex, 0, 0, 2, &other, 0); !errors.Is(err, new(linux.FutexError).Types().WouldBlock) {
+				t.Fatalf("expected would block, got %v", err)
+			}
+			wakeUntil(t, 2, func() (int, error) { return api.RequeueFutex(&futex, 1, 0, math.MaxInt32, &other, 0) })
+			if n, err := api.WakeFutex(&futex, math.MaxInt32, 0); n != 0 || err != nil {
+				t.Fatalf("woke %d waiters that were requeued, %v", n, err)
+			}
+			wakeUntil(t, 2, func() (int, error) { return api.WakeFutex(&other, math.MaxInt32, 0) })
+			for range 2 {
+				if err := <-done; err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var futexes = []linux.FutexToWait{{Futex: &futex, Value: 1}, {Futex: &other, Value: 2}}
+			_, err := api.WaitFutexes(futexes, &past, linux.FutexRealtimeClock)
+			if errors.Is(err, new(linux.FutexError).Types().NotImplemented) {
+				t.Skip("futex_waitv(2) requires Linux 5.16")
+			}
+			if !errors.Is(err, new(linux.FutexError).Types().TimedOut) {
+				t.Fatalf("expected timed out, got %v", err)
+			}
+			var woken = make(chan int, 1)
+			go func() {
+				i, err := api.WaitFutexes(futexes, nil, 0)
+				if err != nil {
+					t.Error(err)
+				}
+				woken <- i
+			}()
+			wakeUntil(t, 1, func() (int, error) { return api.WakeFutex(&other, 1, 0) })
+			if i := <-woken; i != 1 {
+				t.Fatalf("expected the second futex to be woken, got %d", i)
+			}
+		})
+	}
+}
+
+func TestFutexRequeueSameAddress(t *testing.T) {
+	for name, api := range map[string]*linux.API{"Native": linux.Native(), "Memory": linux.Memory()} {
+		t.Run(name, func(t *testing.T) {
+			var futex uint32 = 1
+			var done = make(chan error, 2)
+			for range 2 {
+				go func() { done <- api.WaitFutex(&futex, 1, nil, 0) }()
+			}
+			for i := 0; ; i++ {
+				n, err := api.RequeueFutex(&futex, 1, 0, math.MaxInt32, &futex, 0)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if n == 2 {
+					break
+				}
+				if i == 1000 {
+					t.Fatalf("only %d waiters were requeued", n)
+				}
+				time.Sleep(time.Millisecond)
+			}
+			if n, err := api.WakeFutex(&futex, math.MaxInt32, 0); n != 2 || err != nil {
+				t.Fatalf("woke %d waiters that were requeued onto the same futex, %v", n, err)
+			}
+			for range 2 {
+				if err := <-done; err != nil {
+					t.Fatal(err)
+				}
+			}
+		})
+	}
+}
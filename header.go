@@ -0,0 +1,137 @@
+package linux
+
+import (
+	"io/fs"
+	"time"
+)
+
+const fileTypeMask FilePermissions = 0170000 // S_IFMT
+
+// Type of the file, from the type bits of [FileHeader.Permissions].
+func (h FileHeader) Type() FileType { return FileType(h.Permissions & fileTypeMask) }
+
+// IsDirectory reports whether the file is a directory.
+func (h FileHeader) IsDirectory() bool { return h.Type() == FileTypeDirectory }
+
+// FileInfo returns the header as an [fs.FileInfo] for the file with the given
+// base name, Sys returns the [FileHeader].
+func (h FileHeader) FileInfo(name string) fs.FileInfo { return fileInfo{name: name, header: h} }
+
+type fileInfo struct {
+	name   string
+	header FileHeader
+}
+
+func (info fileInfo) Name() string       { return info.name }
+func (info fileInfo) Size() int64        { return info.header.Size }
+func (info fileInfo) Mode() fs.FileMode  { return info.header.Permissions.AsFileMode() }
+func (info fileInfo) ModTime() time.Time { return info.header.ModifiedAt.AsTime() }
+func (info fileInfo) IsDir() bool        { return info.header.IsDirectory() }
+func (info fileInfo) Sys() any           { return info.header }
+
+// AsFileMode converts the permissions, including the file type, into an
+// [fs.FileMode].
+func (p FilePermissions) AsFileMode() fs.FileMode {
+	var mode = fs.FileMode(p & 0777)
+	switch FileType(p & fileTypeMask) {
+	case FileTypeRegular:
+	case FileTypeDirectory:
+		mode |= fs.ModeDir
+	case FileTypeSymbolicLink:
+		mode |= fs.ModeSymlink
+	case FileTypeSocket:
+		mode |= fs.ModeSocket
+	case FileTypeNamedPipe:
+		mode |= fs.ModeNamedPipe
+	case FileTypeCharacterDevice:
+		mode |= fs.ModeDevice | fs.ModeCharDevice
+	case FileTypeBlockDevice:
+		mode |= fs.ModeDevice
+	default:
+		mode |= fs.ModeIrregular
+	}
+	if p&FileExecutesAsOwner != 0 {
+		mode |= fs.ModeSetuid
+	}
+	if p&FileExecutesAsGroup != 0 {
+		mode |= fs.ModeSetgid
+	}
+	if p&FilesLockedToOwner != 0 {
+		mode |= fs.ModeSticky
+	}
+	return mode
+}
+
+// String formats the permissions in the style of ls -l, for example
+// "drwxr-xr-x", permissions without a file type are formatted as a regular
+// file.
+func (p FilePermissions) String() string {
+	const rwx = "rwxrwxrwx"
+	var s = []byte("----------")
+	switch FileType(p & fileTypeMask) {
+	case 0, FileTypeRegular:
+	case FileTypeDirectory:
+		s[0] = 'd'
+	case FileTypeSymbolicLink:
+		s[0] = 'l'
+	case FileTypeSocket:
+		s[0] = 's'
+	case FileTypeNamedPipe:
+		s[0] = 'p'
+	case FileTypeCharacterDevice:
+		s[0] = 'c'
+	case FileTypeBlockDevice:
+		s[0] = 'b'
+	default:
+		s[0] = '?'
+	}
+	for i := range 9 {
+		if p&(1<<(8-i)) != 0 {
+			s[1+i] = rwx[i]
+		}
+	}
+	var special = func(i int, bit FilePermissions, set, unset byte) {
+		if p&bit != 0 {
+			if s[i] == '-' {
+				s[i] = unset
+			} else {
+				s[i] = set
+			}
+		}
+	}
+	special(3, FileExecutesAsOwner, 's', 'S')
+	special(6, FileExecutesAsGroup, 's', 'S')
+	special(9, FilesLockedToOwner, 't', 'T')
+	return string(s)
+}
+
+// AsTime converts the time into a [time.Time].
+func (t Time) AsTime() time.Time { return time.Unix(t.Seconds, t.Nanos) }
+
+// TimeFrom converts a [time.Time] into a [Time].
+func TimeFrom(t time.Time) Time { return Time{Seconds: t.Unix(), Nanos: int64(t.Nanosecond())} }
+
+// AsDuration converts the time, relative to an unspecified point such as the
+// start of [ClockMonotonic], into a [time.Duration].
+func (t Time) AsDuration() time.Duration {
+	return time.Duration(t.Seconds)*time.Second + time.Duration(t.Nanos)
+}
+
+// TimeFromDuration converts a [time.Duration] into a [Time], for relative
+// timeouts and for clocks other than [ClockRealtime].
+func TimeFromDuration(d time.Duration) Time {
+	return Time{Seconds: int64(d / time.Second), Nanos: int64(d % time.Second)}
+}
+
+// Major number of the device, identifying its driver.
+func (d DeviceID) Major() uint32 { return uint32((d>>8)&0xfff | (d>>32)&0xfffff000) }
+
+// Minor number of the device, identifying it to its driver.
+func (d DeviceID) Minor() uint32 { return uint32(d&0xff | (d>>12)&0xffffff00) }
+
+// NewDeviceID returns the [DeviceID] for the given major and minor numbers.
+func NewDeviceID(major, minor uint32) DeviceID {
+	var d = DeviceID(major&0xfff)<<8 | DeviceID(major&^0xfff)<<32
+	d |= DeviceID(minor&0xff) | DeviceID(minor&^0xff)<<12
+	return d
+}
@@ -0,0 +1,101 @@
+package linux_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"verbose.style/linux"
+)
+
+func TestFileHeader(t *testing.T) {
+	var api = linux.Native()
+	var dir = t.TempDir()
+	var file = filepath.Join(dir, "file")
+	if err := os.WriteFile(file, []byte("hello"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("file", filepath.Join(dir, "link")); err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		name     string
+		stat     func(linux.Path) (linux.FileHeader, error)
+		fileType linux.FileType
+	}{
+		{"", api.Stat, linux.FileTypeDirectory},
+		{"file", api.Stat, linux.FileTypeRegular},
+		{"link", api.StatLink, linux.FileTypeSymbolicLink},
+	} {
+		var path = filepath.Join(dir, test.name)
+		header, err := test.stat(linux.Path(path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if header.Type() != test.fileType || header.IsDirectory() != (test.fileType == linux.FileTypeDirectory) {
+			t.Errorf("%s has type %v", path, header.Type())
+		}
+		expected, err := os.Lstat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var info = header.FileInfo(filepath.Base(path))
+		if info.Name() != expected.Name() || info.Size() != expected.Size() || info.Mode() != expected.Mode() ||
+			!info.ModTime().Equal(expected.ModTime()) || info.IsDir() != expected.IsDir() {
+			t.Errorf("%s has info %v %v %v %v, expected %v %v %v %v", path,
+				info.Name(), info.Size(), info.Mode(), info.ModTime(),
+				expected.Name(), expected.Size(), expected.Mode(), expected.ModTime())
+		}
+	}
+	header, err := api.Stat("/dev/null")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Type() != linux.FileTypeCharacterDevice || header.Special.Major() != 1 || header.Special.Minor() != 3 {
+		t.Error(header.Type(), header.Special.Major(), header.Special.Minor())
+	}
+	if header.Permissions.String() != "crw-rw-rw-" {
+		t.Error(header.Permissions)
+	}
+}
+
+func TestFilePermissionsString(t *testing.T) {
+	for _, test := range []struct {
+		permissions linux.FilePermissions
+		text        string
+	}{
+		{0644, "-rw-r--r--"},
+		{linux.FilePermissions(linux.FileTypeDirectory) | 0755, "drwxr-xr-x"},
+		{linux.FilePermissions(linux.FileTypeSymbolicLink) | 0777, "lrwxrwxrwx"},
+		{linux.FilePermissions(linux.FileTypeDirectory) | linux.FilesLockedToOwner | 0777, "drwxrwxrwt"},
+		{linux.FilesLockedToOwner | 0700, "-rwx-----T"},
+		{linux.FileExecutesAsOwner | linux.FileExecutesAsGroup | 0745, "-rwsr-Sr-x"},
+		{linux.FilePermissions(linux.FileTypeNamedPipe) | 0600, "prw-------"},
+		{linux.FilePermissions(linux.FileTypeBlockDevice) | 0660, "brw-rw----"},
+		{linux.FilePermissions(linux.FileTypeSocket) | 0777, "srwxrwxrwx"},
+	} {
+		if text := test.permissions.String(); text != test.text {
+			t.Errorf("%o is %s, expected %s", test.permissions, text, test.text)
+		}
+	}
+}
+
+func TestTime(t *testing.T) {
+	var now = time.Unix(1700000000, 123456789)
+	if got := linux.TimeFrom(now); got.Seconds != 1700000000 || got.Nanos != 123456789 || !got.AsTime().Equal(now) {
+		t.Fatal(got)
+	}
+}
+
+func TestDeviceID(t *testing.T) {
+	for _, device := range [][2]uint32{{0, 0}, {1, 3}, {8, 1}, {259, 65537}, {0xfffff, 0xfffff}} {
+		var id = linux.NewDeviceID(device[0], device[1])
+		if id.Major() != device[0] || id.Minor() != device[1] {
+			t.Errorf("%v became %d:%d", device, id.Major(), id.Minor())
+		}
+	}
+	if id := linux.NewDeviceID(8, 1); id != 0x801 {
+		t.Errorf("%#x", id)
+	}
+}
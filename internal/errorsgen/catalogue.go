@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+)
+
+// Catalogue of the errnos that each system call can return, along with the
+// error types that name them.
+type Catalogue struct {
+	Syscalls map[string][]string `json:"syscalls"` // errnos by system call, as documented in the man pages.
+	Errors   []ErrorType         `json:"errors"`
+}
+
+// ErrorType to generate.
+type ErrorType struct {
+	Type     string       `json:"type"`
+	Doc      string       `json:"doc"`
+	Syscalls []string     `json:"syscalls"` // system calls that return this error type.
+	Fields   []ErrorField `json:"fields"`
+}
+
+// ErrorField names an errno of an [ErrorType].
+type ErrorField struct {
+	Name  string `json:"name"`
+	Errno string `json:"errno"`
+	Doc   string `json:"doc"`
+}
+
+func generateTypes(catalogue, output string, constants map[string]syscall.Errno) error {
+	data, err := os.ReadFile(catalogue)
+	if err != nil {
+		return err
+	}
+	var table Catalogue
+	if err := json.Unmarshal(data, &table); err != nil {
+		return fmt.Errorf("%s: %w", catalogue, err)
+	}
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by \"go run ./internal/errorsgen -catalogue %s\"; DO NOT EDIT.\n\n", catalogue)
+	fmt.Fprintf(&src, "package linux\n")
+	for _, etype := range table.Errors {
+		var named = make(map[syscall.Errno]bool)
+		for _, field := range etype.Fields {
+			errno, ok := constants[field.Errno]
+			if !ok {
+				return fmt.Errorf("%s.%s: unknown errno %s", etype.Type, field.Name, field.Errno)
+			}
+			if named[errno] {
+				return fmt.Errorf("%s.%s: %s is already named", etype.Type, field.Name, field.Errno)
+			}
+			named[errno] = true
+		}
+		var missing []string
+		for _, call := range etype.Syscalls {
+			errnos, ok := table.Syscalls[call]
+			if !ok {
+				return fmt.Errorf("%s: unknown system call %s", etype.Type, call)
+			}
+			for _, name := range errnos {
+				if errno, ok := constants[name]; !ok || !named[errno] {
+					missing = append(missing, call+":"+name)
+				}
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("%s does not name %s", etype.Type, strings.Join(missing, ", "))
+		}
+		fmt.Fprintf(&src, "\n// %s\ntype %s Error[struct {\n", etype.Doc, etype.Type)
+		for _, field := range etype.Fields {
+			fmt.Fprintf(&src, "\t%s %s `%s` // %s\n", field.Name, etype.Type, constants[field.Errno].Error(), field.Doc)
+		}
+		fmt.Fprintf(&src, "}]\n")
+	}
+	return write(output, src.Bytes())
+}
@@ -0,0 +1,175 @@
+// Command errorsgen generates the error types and their errno lookup tables.
+//
+// With -catalogue, it generates the error type declarations from a table of
+// the errnos that each system call can return (see errnos.json), failing when
+// an error type does not name an errno that one of its system calls can return.
+//
+// Otherwise, it generates the errno lookup tables for the error types declared
+// in the input, so that mapping an errno to its error does not need reflection.
+// The struct tags of each error type are the source of truth for the tables,
+// each tag is the message of the errno that identifies the field.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+func main() {
+	var catalogue = flag.String("catalogue", "", "table of errnos to generate the error types from")
+	var input = flag.String("input", "errors_types.go", "file that declares the error types")
+	var output = flag.String("output", "errors_table.go", "file to write to")
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("errorsgen: ")
+
+	constants, err := errnoConstants()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *catalogue != "" {
+		if err := generateTypes(*catalogue, *output, constants); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	var names = errnoNames(constants)
+	var fset = token.NewFileSet()
+	file, err := parser.ParseFile(fset, *input, nil, parser.SkipObjectResolution)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by \"go run ./internal/errorsgen -input %s\"; DO NOT EDIT.\n\n", *input)
+	fmt.Fprintf(&src, "package %s\n\nimport \"syscall\"\n\nfunc init() {\n", file.Name.Name)
+	for _, decl := range file.Decls {
+		decl, ok := decl.(*ast.GenDecl)
+		if !ok || decl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range decl.Specs {
+			spec := spec.(*ast.TypeSpec)
+			index, ok := spec.Type.(*ast.IndexExpr)
+			if !ok || !isIdent(index.X, "Error") {
+				continue
+			}
+			fields, ok := index.Index.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			if err := generate(&src, spec.Name.Name, fields, names); err != nil {
+				log.Fatalf("%s: %v", fset.Position(spec.Pos()), err)
+			}
+		}
+	}
+	fmt.Fprintf(&src, "}\n")
+	if err := write(*output, src.Bytes()); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func write(name string, src []byte) error {
+	formatted, err := format.Source(src)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(name, formatted, 0666)
+}
+
+func isIdent(expr ast.Expr, name string) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == name
+}
+
+// generate the table for the error type called name, with the given fields.
+func generate(w *bytes.Buffer, name string, fields *ast.StructType, names map[string]string) error {
+	var lower = strings.ToLower(name[:1]) + name[1:]
+	var table strings.Builder
+	fmt.Fprintf(w, "\tvar %s %s\n", lower, name)
+	fmt.Fprintf(w, "\tvar %sTypes = zeroOf(%s.ErrMethods)\n", lower, lower)
+	for _, field := range fields.Fields.List {
+		if field.Tag == nil || len(field.Names) != 1 {
+			return fmt.Errorf("%s fields must be named and tagged with the message of their errno", name)
+		}
+		tag, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			return err
+		}
+		errno, ok := names[tag]
+		if !ok {
+			return fmt.Errorf("%s.%s: no errno has the message %q", name, field.Names[0].Name, tag)
+		}
+		fmt.Fprintf(w, "\tsetErrno(&%sTypes.%s.ErrMethods, syscall.%s)\n", lower, field.Names[0].Name, errno)
+		fmt.Fprintf(&table, "\t\t\tsyscall.%s: %q,\n", errno, field.Names[0].Name)
+	}
+	fmt.Fprintf(w, "\terrorTables[%s.ErrMethods] = &errorTable{\n", lower)
+	fmt.Fprintf(w, "\t\ttypes: %sTypes,\n", lower)
+	fmt.Fprintf(w, "\t\tnames: []string{\n%s\t\t},\n", table.String())
+	fmt.Fprintf(w, "\t\tparse: func(errno syscall.Errno) error {\n")
+	fmt.Fprintf(w, "\t\t\tvar err %s\n\t\t\tsetErrno(&err.ErrMethods, errno)\n\t\t\treturn err\n\t\t},\n\t}\n", name)
+	fmt.Fprintf(w, "\terrorTypes[%q] = errorTables[%s.ErrMethods]\n", name, lower)
+	return nil
+}
+
+// errnoConstants returns the values of the errno constants in package syscall,
+// by their name.
+func errnoConstants() (map[string]syscall.Errno, error) {
+	pkg, err := importer.ForCompiler(token.NewFileSet(), "source", nil).Import("syscall")
+	if err != nil {
+		return nil, err
+	}
+	var errno = reflect.TypeFor[syscall.Errno]().Name()
+	var scope = pkg.Scope()
+	var constants = make(map[string]syscall.Errno)
+	for _, name := range scope.Names() {
+		object, ok := scope.Lookup(name).(*types.Const)
+		if !ok || !strings.HasPrefix(name, "E") {
+			continue
+		}
+		if named, ok := object.Type().(*types.Named); !ok || named.Obj().Name() != errno {
+			continue
+		}
+		if value, ok := constant.Uint64Val(object.Val()); ok {
+			constants[name] = syscall.Errno(value)
+		}
+	}
+	return constants, nil
+}
+
+// errnoNames returns the names of the errno constants by their message. When
+// multiple constants share an errno, the shortest name is used.
+func errnoNames(constants map[string]syscall.Errno) map[string]string {
+	var sorted = make([]string, 0, len(constants))
+	for name := range constants {
+		sorted = append(sorted, name)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if len(sorted[i]) != len(sorted[j]) {
+			return len(sorted[i]) < len(sorted[j])
+		}
+		return sorted[i] < sorted[j]
+	})
+	var names = make(map[string]string)
+	for _, name := range sorted {
+		var msg = constants[name].Error()
+		if _, ok := names[msg]; !ok {
+			names[msg] = name
+		}
+	}
+	return names
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestCatalogue fails when an error type does not name an errno that one of its
+// system calls can return, or when the generated files are out of date.
+func TestCatalogue(t *testing.T) {
+	constants, err := errnoConstants()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dir = t.TempDir()
+	var types = filepath.Join(dir, "errors_types.go")
+	if err := generateTypes("errnos.json", types, constants); err != nil {
+		t.Fatal(err)
+	}
+	expected, err := os.ReadFile("../../errors_types.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(types)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = bytes.Replace(got, []byte("-catalogue errnos.json"), []byte("-catalogue internal/errorsgen/errnos.json"), 1)
+	if !bytes.Equal(got, expected) {
+		t.Fatal("errors_types.go is out of date, run go generate")
+	}
+}
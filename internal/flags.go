@@ -0,0 +1,174 @@
+// Code generated by "go run ./internal/flagsgen"; DO NOT EDIT.
+
+package internal
+
+import (
+	"testing"
+
+	"verbose.style/linux"
+)
+
+// #include <linux/fcntl.h>
+// #include <sys/stat.h>
+// #include <linux/unistd.h>
+// #include <linux/mman.h>
+// #include <linux/poll.h>
+// #include <linux/fs.h>
+// #include <sys/eventfd.h>
+// #include <linux/memfd.h>
+// #include <linux/futex.h>
+// #include <time.h>
+// #ifndef MAP_32BIT
+// #define MAP_32BIT 0
+// #endif
+// #ifndef MADV_COLLAPSE
+// #define MADV_COLLAPSE 25
+// #endif
+// #ifndef CLOCK_TAI
+// #define CLOCK_TAI 11
+// #endif
+import "C"
+
+func testFlags(t *testing.T) {
+	var _ linux.MemoryProtection
+	assert(t, linux.MemoryNotAccessible, C.PROT_NONE)
+	assert(t, linux.MemoryAllowReads, C.PROT_READ)
+	assert(t, linux.MemoryAllowWrites, C.PROT_WRITE)
+	assert(t, linux.MemoryAllowExecution, C.PROT_EXEC)
+	assert(t, linux.MemoryAllowAtomics, C.PROT_SEM)
+	var _ linux.MapType
+	assert(t, linux.MapShared, C.MAP_SHARED)
+	assert(t, linux.MapPrivate, C.MAP_PRIVATE)
+	assert(t, linux.MapSharedValidateFlags, C.MAP_SHARED_VALIDATE)
+	var _ linux.Map
+	assert(t, linux.MapAnonymous, C.MAP_ANONYMOUS)
+	assert(t, linux.Map32Bit, C.MAP_32BIT)
+	assert(t, linux.MapExactAddress, C.MAP_FIXED)
+	assert(t, linux.MapExactAddressOnce, C.MAP_FIXED_NOREPLACE)
+	assert(t, linux.MapGrowsDown, C.MAP_GROWSDOWN)
+	assert(t, linux.MapHugeTables, C.MAP_HUGETLB)
+	assert(t, linux.MapHuge2MB, C.MAP_HUGE_2MB)
+	assert(t, linux.MapHuge1GB, C.MAP_HUGE_1GB)
+	assert(t, linux.MapKeepAwayFromSwap, C.MAP_LOCKED)
+	assert(t, linux.MapDoNotReserveSwap, C.MAP_NORESERVE)
+	assert(t, linux.MapPopulate, C.MAP_POPULATE)
+	assert(t, linux.MapStack, C.MAP_STACK)
+	assert(t, linux.MapSync, C.MAP_SYNC)
+	assert(t, linux.MapUninitialized, C.MAP_UNINITIALIZED)
+	var _ linux.Poll
+	assert(t, linux.PollHasReadAvailable, C.POLLIN)
+	assert(t, linux.PollHasPriority, C.POLLPRI)
+	assert(t, linux.PollHasWriteAvailable, C.POLLOUT)
+	assert(t, linux.PollHasPeerFinishedWriting, C.POLLRDHUP)
+	assert(t, linux.PollHasPeerConnectionClosed, C.POLLHUP)
+	assert(t, linux.PollHasError, C.POLLERR)
+	assert(t, linux.PollHasInvalidRequest, C.POLLNVAL)
+	var _ linux.Remap
+	assert(t, linux.RemapMayMove, C.MREMAP_MAYMOVE)
+	assert(t, linux.RemapExactAddress, C.MREMAP_FIXED)
+	assert(t, linux.RemapDoNotUnmap, C.MREMAP_DONTUNMAP)
+	var _ linux.MemoryAdvice
+	assert(t, linux.MemoryAccessNormally, C.MADV_NORMAL)
+	assert(t, linux.MemoryAccessRandomly, C.MADV_RANDOM)
+	assert(t, linux.MemoryAccessSequentially, C.MADV_SEQUENTIAL)
+	assert(t, linux.MemoryWillBeNeeded, C.MADV_WILLNEED)
+	assert(t, linux.MemoryNotNeeded, C.MADV_DONTNEED)
+	assert(t, linux.MemoryFree, C.MADV_FREE)
+	assert(t, linux.MemoryRemove, C.MADV_REMOVE)
+	assert(t, linux.MemoryDoNotFork, C.MADV_DONTFORK)
+	assert(t, linux.MemoryDoFork, C.MADV_DOFORK)
+	assert(t, linux.MemoryMergeable, C.MADV_MERGEABLE)
+	assert(t, linux.MemoryUnmergeable, C.MADV_UNMERGEABLE)
+	assert(t, linux.MemoryHugePages, C.MADV_HUGEPAGE)
+	assert(t, linux.MemoryNoHugePages, C.MADV_NOHUGEPAGE)
+	assert(t, linux.MemoryDoNotDump, C.MADV_DONTDUMP)
+	assert(t, linux.MemoryDoDump, C.MADV_DODUMP)
+	assert(t, linux.MemoryCold, C.MADV_COLD)
+	assert(t, linux.MemoryPageOut, C.MADV_PAGEOUT)
+	assert(t, linux.MemoryPopulateRead, C.MADV_POPULATE_READ)
+	assert(t, linux.MemoryPopulateWrite, C.MADV_POPULATE_WRITE)
+	assert(t, linux.MemoryCollapse, C.MADV_COLLAPSE)
+	var _ linux.MemoryLock
+	assert(t, linux.MemoryLockOnFault, C.MLOCK_ONFAULT)
+	var _ linux.MemorySync
+	assert(t, linux.MemorySyncAsynchronously, C.MS_ASYNC)
+	assert(t, linux.MemorySyncInvalidate, C.MS_INVALIDATE)
+	assert(t, linux.MemorySyncSynchronously, C.MS_SYNC)
+	var _ linux.MemoryFileFlags
+	assert(t, linux.MemoryFileCloseOnExecute, C.MFD_CLOEXEC)
+	assert(t, linux.MemoryFileAllowSealing, C.MFD_ALLOW_SEALING)
+	assert(t, linux.MemoryFileHugeTables, C.MFD_HUGETLB)
+	var _ linux.Clock
+	assert(t, linux.ClockRealtime, C.CLOCK_REALTIME)
+	assert(t, linux.ClockMonotonic, C.CLOCK_MONOTONIC)
+	assert(t, linux.ClockProcessTime, C.CLOCK_PROCESS_CPUTIME_ID)
+	assert(t, linux.ClockThreadTime, C.CLOCK_THREAD_CPUTIME_ID)
+	assert(t, linux.ClockBoottime, C.CLOCK_BOOTTIME)
+	assert(t, linux.ClockAtomicTime, C.CLOCK_TAI)
+	var _ linux.Sleep
+	assert(t, linux.SleepUntil, C.TIMER_ABSTIME)
+	var _ linux.FutexFlags
+	assert(t, linux.FutexPrivate, C.FUTEX_PRIVATE_FLAG)
+	assert(t, linux.FutexRealtimeClock, C.FUTEX_CLOCK_REALTIME)
+	var _ linux.Seek
+	assert(t, linux.SeekRelativeToStart, C.SEEK_SET)
+	assert(t, linux.SeekRelative, C.SEEK_CUR)
+	assert(t, linux.SeekRelativeToEnd, C.SEEK_END)
+	assert(t, linux.SeekHole, C.SEEK_HOLE)
+	assert(t, linux.SeekData, C.SEEK_DATA)
+	var _ linux.Rename
+	assert(t, linux.RenameDoNotReplace, C.RENAME_NOREPLACE)
+	assert(t, linux.RenameExchange, C.RENAME_EXCHANGE)
+	assert(t, linux.RenameWhiteout, C.RENAME_WHITEOUT)
+	var _ linux.EventFlags
+	assert(t, linux.EventCloseOnExecute, C.EFD_CLOEXEC)
+	assert(t, linux.EventNonBlocking, C.EFD_NONBLOCK)
+	assert(t, linux.EventSemaphore, C.EFD_SEMAPHORE)
+	var _ linux.FileCreationFlags
+	assert(t, linux.FileCloseOnExecute, C.O_CLOEXEC)
+	assert(t, linux.FileCreateIfNeeded, C.O_CREAT)
+	assert(t, linux.FileAssertDirectory, C.O_DIRECTORY)
+	assert(t, linux.FileAssertCreation, C.O_EXCL)
+	assert(t, linux.FileIsNotTheTerminal, C.O_NOCTTY)
+	assert(t, linux.FileTrapSymbolicLink, C.O_NOFOLLOW)
+	assert(t, linux.FileTemporaryInside, C.O_TMPFILE)
+	assert(t, linux.FileTruncatedToZero, C.O_TRUNC)
+	var _ linux.FileStatusFlags
+	assert(t, linux.FileAppend, C.O_APPEND)
+	assert(t, linux.FileAsync, C.FASYNC)
+	assert(t, linux.FileDirect, C.O_DIRECT)
+	assert(t, linux.FileSyncData, C.O_DSYNC)
+	assert(t, linux.FileDoNotUpdateAccessTime, C.O_NOATIME)
+	assert(t, linux.FileNonBlocking, C.O_NONBLOCK)
+	assert(t, linux.FilePath, C.O_PATH)
+	assert(t, linux.FileSync, C.O_SYNC)
+	var _ linux.FilePermissions
+	assert(t, linux.FileReadableByUser, C.S_IRUSR)
+	assert(t, linux.FileReadableByGroup, C.S_IRGRP)
+	assert(t, linux.FileReadableByOthers, C.S_IROTH)
+	assert(t, linux.FileWritableByUser, C.S_IWUSR)
+	assert(t, linux.FileWritableByGroup, C.S_IWGRP)
+	assert(t, linux.FileWritableByOthers, C.S_IWOTH)
+	assert(t, linux.FileExecutableByUser, C.S_IXUSR)
+	assert(t, linux.FileExecutableByGroup, C.S_IXGRP)
+	assert(t, linux.FileExecutableByOthers, C.S_IXOTH)
+	assert(t, linux.FileExecutesAsOwner, C.S_ISUID)
+	assert(t, linux.FileExecutesAsGroup, C.S_ISGID)
+	assert(t, linux.FilesInheritGroup, C.S_ISGID)
+	assert(t, linux.FilesLockedToOwner, C.S_ISVTX)
+	assert(t, linux.DirectorySearchableByUser, C.S_IXUSR)
+	assert(t, linux.DirectorySearchableByGroup, C.S_IXGRP)
+	assert(t, linux.DirectorySearchableByOthers, C.S_IXOTH)
+	var _ linux.FileType
+	assert(t, linux.FileTypeRegular, C.S_IFREG)
+	assert(t, linux.FileTypeDirectory, C.S_IFDIR)
+	assert(t, linux.FileTypeSymbolicLink, C.S_IFLNK)
+	assert(t, linux.FileTypeSocket, C.S_IFSOCK)
+	assert(t, linux.FileTypeNamedPipe, C.S_IFIFO)
+	assert(t, linux.FileTypeCharacterDevice, C.S_IFCHR)
+	assert(t, linux.FileTypeBlockDevice, C.S_IFBLK)
+	var _ linux.FileAccessMode
+	assert(t, linux.FileAccessReadOnly, C.O_RDONLY)
+	assert(t, linux.FileAccessWriteOnly, C.O_WRONLY)
+	assert(t, linux.FileAccessReadWrite, C.O_RDWR)
+}
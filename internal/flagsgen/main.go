@@ -0,0 +1,369 @@
+// Command flagsgen generates the flag types and constants from a table of the
+// C macros that define them (see flags.json), along with the cgo assertions in
+// the internal package that check them against the headers.
+//
+// The value of each macro is resolved with cgo -godefs for each architecture,
+// using the C compiler for the architecture (CC_386, CC_amd64, CC_arm64,
+// CC_riscv64 or a default cross compiler). Constants with the same value on
+// every architecture are written to the output, the rest to the output with
+// an _arch suffix, and with a _generic suffix for the other architectures,
+// which take the values of riscv64, as it only uses the asm-generic headers
+// (see [genericArch]). With -godefs, the output of cgo -godefs for an architecture
+// can be read from dir/arch.go instead.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log"
+	"math/bits"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Catalogue of flag types, by the C macros that define their constants.
+type Catalogue struct {
+	Headers []string   `json:"headers"` // C headers that define the macros.
+	Types   []FlagType `json:"types"`
+}
+
+// FlagType to generate.
+type FlagType struct {
+	Type       string     `json:"type"`
+	Underlying string     `json:"underlying"`
+	Format     string     `json:"format,omitempty"` // "hex" (default), "octal" or "decimal".
+	Kind       string     `json:"kind,omitempty"`   // "flags" or "enum" to generate String and Parse, see text.go.
+	Doc        string     `json:"doc"`
+	Constants  []Constant `json:"constants"`
+}
+
+// Constant of a [FlagType].
+type Constant struct {
+	Name     string `json:"name"`
+	Macro    string `json:"macro"`
+	Optional bool   `json:"optional,omitempty"` // zero on architectures that do not define the macro.
+	Fallback *int64 `json:"fallback,omitempty"` // value of the macro, for headers that predate it.
+	Doc      string `json:"doc"`
+}
+
+// compilers for each architecture, when CC_arch is not set.
+var compilers = map[string]string{
+	"386":     "i686-linux-gnu-gcc",
+	"amd64":   "x86_64-linux-gnu-gcc",
+	"arm64":   "aarch64-linux-gnu-gcc",
+	"riscv64": "riscv64-linux-gnu-gcc",
+}
+
+func main() {
+	var catalogue = flag.String("catalogue", "internal/flagsgen/flags.json", "table of flags to generate")
+	var arches = flag.String("arch", "386,amd64,arm64,riscv64", "architectures to generate constants for")
+	var godefs = flag.String("godefs", "", "directory to read the output of cgo -godefs from, for architectures with an arch.go file")
+	var output = flag.String("output", "flags.go", "file to write the flag types to")
+	var assertions = flag.String("assertions", "internal/flags.go", "file to write the cgo assertions to")
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("flagsgen: ")
+
+	data, err := os.ReadFile(*catalogue)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var cat Catalogue
+	if err := json.Unmarshal(data, &cat); err != nil {
+		log.Fatalf("%s: %v", *catalogue, err)
+	}
+	var values = make(map[string]map[string]int64)
+	for _, arch := range strings.Split(*arches, ",") {
+		defs, err := readGodefs(*godefs, arch, &cat)
+		if err != nil {
+			log.Fatalf("%s: %v", arch, err)
+		}
+		if values[arch], err = resolve(arch, defs, &cat); err != nil {
+			log.Fatalf("%s: %v", arch, err)
+		}
+	}
+	for name, src := range generate(&cat, values) {
+		var file = *output
+		if name != "" {
+			file = strings.TrimSuffix(file, ".go") + "_" + name + ".go"
+		}
+		if err := write(file, src); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if err := write(*assertions, generateAssertions(&cat)); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// genericArch provides the values of the architecture specific constants on
+// the architectures that they are not generated for, where only the Memory
+// API interprets them.
+const genericArch = "riscv64"
+
+// genericConstraint excludes the architectures that the constants were
+// generated for.
+func genericConstraint(arches []string) string {
+	var excluded []string
+	for _, arch := range slices.Sorted(slices.Values(arches)) {
+		excluded = append(excluded, "!"+arch)
+	}
+	return "//go:build " + strings.Join(excluded, " && ")
+}
+
+// preamble for cgo, optional macros default to zero, or to their fallback.
+func (cat *Catalogue) preamble() string {
+	var s strings.Builder
+	for _, header := range cat.Headers {
+		fmt.Fprintf(&s, "// #include <%s>\n", header)
+	}
+	for _, t := range cat.Types {
+		for _, c := range t.Constants {
+			if c.Optional || c.Fallback != nil {
+				var fallback int64
+				if c.Fallback != nil {
+					fallback = *c.Fallback
+				}
+				fmt.Fprintf(&s, "// #ifndef %[1]s\n// #define %[1]s %[2]d\n// #endif\n", c.Macro, fallback)
+			}
+		}
+	}
+	return s.String()
+}
+
+// readGodefs returns the constants converted by cgo -godefs for arch.
+func readGodefs(dir, arch string, cat *Catalogue) ([]byte, error) {
+	if dir != "" {
+		defs, err := os.ReadFile(filepath.Join(dir, arch+".go"))
+		if err == nil || !os.IsNotExist(err) {
+			return defs, err
+		}
+	}
+	tmp, err := os.MkdirTemp("", "flagsgen")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	var input bytes.Buffer
+	fmt.Fprintf(&input, "package main\n\n%simport \"C\"\n\nconst (\n", cat.preamble())
+	for _, t := range cat.Types {
+		for _, c := range t.Constants {
+			fmt.Fprintf(&input, "\t%s = C.%s\n", c.Name, c.Macro)
+		}
+	}
+	fmt.Fprintf(&input, ")\n")
+	if err := os.WriteFile(filepath.Join(tmp, "input.go"), input.Bytes(), 0666); err != nil {
+		return nil, err
+	}
+	var cc = os.Getenv("CC_" + arch)
+	if cc == "" {
+		cc = compilers[arch]
+		if arch == runtime.GOARCH {
+			cc = "cc"
+		}
+	}
+	var cmd = exec.Command("go", "tool", "cgo", "-godefs", "input.go")
+	cmd.Dir = tmp
+	cmd.Env = append(os.Environ(), "GOARCH="+arch, "CC="+cc)
+	cmd.Stderr = os.Stderr
+	return cmd.Output()
+}
+
+// resolve the value of each constant in the cgo -godefs output for arch.
+func resolve(arch string, src []byte, cat *Catalogue) (map[string]int64, error) {
+	var fset = token.NewFileSet()
+	file, err := parser.ParseFile(fset, arch+".go", src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+	var config = types.Config{Sizes: types.SizesFor("gc", arch)}
+	pkg, err := config.Check("godefs", fset, []*ast.File{file}, nil)
+	if err != nil {
+		return nil, err
+	}
+	var values = make(map[string]int64)
+	for _, t := range cat.Types {
+		for _, c := range t.Constants {
+			object, ok := pkg.Scope().Lookup(c.Name).(*types.Const)
+			if !ok {
+				return nil, fmt.Errorf("cgo -godefs is missing %s (%s)", c.Name, c.Macro)
+			}
+			value, exact := constant.Int64Val(object.Val())
+			if !exact {
+				return nil, fmt.Errorf("%s (%s) is not an integer", c.Name, c.Macro)
+			}
+			values[c.Name] = value
+		}
+	}
+	return values, nil
+}
+
+// generate the flag types, by the architecture that they are specific to, or
+// "" for those that are the same on every architecture.
+func generate(cat *Catalogue, values map[string]map[string]int64) map[string][]byte {
+	var arches []string
+	for arch := range values {
+		arches = append(arches, arch)
+	}
+	sort.Strings(arches)
+	var files = make(map[string]*bytes.Buffer)
+	var file = func(arch string) *bytes.Buffer {
+		if files[arch] == nil {
+			files[arch] = new(bytes.Buffer)
+			fmt.Fprintf(files[arch], "// Code generated by \"go run ./internal/flagsgen\"; DO NOT EDIT.\n\n")
+			if arch == "generic" {
+				fmt.Fprintf(files[arch], "%s\n\n", genericConstraint(arches))
+			}
+			fmt.Fprintf(files[arch], "package linux\n")
+		}
+		return files[arch]
+	}
+	for _, t := range cat.Types {
+		var common, specific []Constant
+		for _, c := range t.Constants {
+			if sameOnEvery(arches, values, c.Name) {
+				common = append(common, c)
+			} else {
+				specific = append(specific, c)
+			}
+		}
+		var src = file("")
+		fmt.Fprintf(src, "\n")
+		for _, line := range strings.Split(t.Doc, "\n") {
+			fmt.Fprintf(src, "// %s\n", line)
+		}
+		fmt.Fprintf(src, "type %s %s\n", t.Type, t.Underlying)
+		writeConstants(src, t, common, values[arches[0]])
+		writeText(src, t, arches, values)
+		if len(specific) > 0 {
+			for _, arch := range arches {
+				writeConstants(file(arch), t, specific, values[arch])
+			}
+			if values[genericArch] != nil {
+				writeConstants(file("generic"), t, specific, values[genericArch])
+			}
+		}
+	}
+	var sources = make(map[string][]byte)
+	for arch, src := range files {
+		sources[arch] = src.Bytes()
+	}
+	return sources
+}
+
+// writeText writes the String, Parse, MarshalText and UnmarshalText functions
+// for flags and enums. The names of flags are ordered by the number of bits
+// that they span, so that values spanning multiple bits are named first.
+func writeText(src *bytes.Buffer, t FlagType, arches []string, values map[string]map[string]int64) {
+	if t.Kind != "flags" && t.Kind != "enum" {
+		return
+	}
+	var names = lowerFirst(t.Type) + "Names"
+	var constants = slices.Clone(t.Constants)
+	var zero = "0"
+	if t.Kind == "flags" {
+		var popcount = func(c Constant) (count int) {
+			for _, arch := range arches {
+				count = max(count, bits.OnesCount64(uint64(values[arch][c.Name])))
+			}
+			return count
+		}
+		slices.SortStableFunc(constants, func(a, b Constant) int { return popcount(b) - popcount(a) })
+		for _, c := range t.Constants {
+			if !c.Optional && popcount(c) == 0 {
+				zero = c.Name
+				break
+			}
+		}
+	}
+	fmt.Fprintf(src, "\nvar %s = []flagName[%s]{\n", names, t.Type)
+	for _, c := range constants {
+		fmt.Fprintf(src, "\t{%q, %s},\n", c.Name, c.Name)
+	}
+	fmt.Fprintf(src, "}\n\n")
+	if t.Kind == "flags" {
+		fmt.Fprintf(src, "func (v %s) String() string { return formatFlags(v, %q, %s) }\n\n", t.Type, zero, names)
+		fmt.Fprintf(src, "// Parse%[1]s parses names of [%[1]s] separated by '|', as formatted by [%[1]s.String].\n", t.Type)
+		fmt.Fprintf(src, "func Parse%[1]s(s string) (%[1]s, error) { return parseFlags(%[1]q, s, %[2]s) }\n\n", t.Type, names)
+	} else {
+		fmt.Fprintf(src, "func (v %s) String() string { return formatEnum(v, %s) }\n\n", t.Type, names)
+		fmt.Fprintf(src, "// Parse%[1]s parses the name of a [%[1]s], as formatted by [%[1]s.String].\n", t.Type)
+		fmt.Fprintf(src, "func Parse%[1]s(s string) (%[1]s, error) { return parseEnum(%[1]q, s, %[2]s) }\n\n", t.Type, names)
+	}
+	fmt.Fprintf(src, "func (v %s) MarshalText() ([]byte, error) { return []byte(v.String()), nil }\n\n", t.Type)
+	fmt.Fprintf(src, "func (v *%[1]s) UnmarshalText(text []byte) (err error) {\n\t*v, err = Parse%[1]s(string(text))\n\treturn err\n}\n", t.Type)
+}
+
+func lowerFirst(s string) string { return strings.ToLower(s[:1]) + s[1:] }
+
+func sameOnEvery(arches []string, values map[string]map[string]int64, name string) bool {
+	for _, arch := range arches {
+		if values[arch][name] != values[arches[0]][name] {
+			return false
+		}
+	}
+	return true
+}
+
+func writeConstants(src *bytes.Buffer, t FlagType, constants []Constant, values map[string]int64) {
+	if len(constants) == 0 {
+		return
+	}
+	fmt.Fprintf(src, "\nconst (\n")
+	for _, c := range constants {
+		fmt.Fprintf(src, "\t%s %s = %s // %s\n", c.Name, t.Type, formatValue(t.Format, values[c.Name]), c.Doc)
+	}
+	fmt.Fprintf(src, ")\n")
+}
+
+func formatValue(format string, value int64) string {
+	switch {
+	case format == "decimal", value < 0:
+		return strconv.FormatInt(value, 10)
+	case format == "octal" && value != 0:
+		return "0" + strconv.FormatInt(value, 8)
+	case format == "octal":
+		return "0"
+	default:
+		return "0x" + strconv.FormatInt(value, 16)
+	}
+}
+
+// generateAssertions of the cgo test that checks each constant against the
+// headers of the architecture it is built for.
+func generateAssertions(cat *Catalogue) []byte {
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by \"go run ./internal/flagsgen\"; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "package internal\n\nimport (\n\t\"testing\"\n\n\t\"verbose.style/linux\"\n)\n\n")
+	fmt.Fprintf(&src, "%simport \"C\"\n\nfunc testFlags(t *testing.T) {\n", cat.preamble())
+	for _, t := range cat.Types {
+		fmt.Fprintf(&src, "\tvar _ linux.%s\n", t.Type)
+		for _, c := range t.Constants {
+			fmt.Fprintf(&src, "\tassert(t, linux.%s, C.%s)\n", c.Name, c.Macro)
+		}
+	}
+	fmt.Fprintf(&src, "}\n")
+	return src.Bytes()
+}
+
+func write(name string, src []byte) error {
+	formatted, err := format.Source(src)
+	if err != nil {
+		return fmt.Errorf("%s: %v", name, err)
+	}
+	return os.WriteFile(name, formatted, 0666)
+}
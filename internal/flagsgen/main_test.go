@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/build"
+	"go/constant"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// TestCatalogue checks that every constant in the catalogue is declared with
+// its type on each architecture, and that the constants for the host match the
+// headers, as resolved by cgo -godefs.
+func TestCatalogue(t *testing.T) {
+	data, err := os.ReadFile("flags.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cat Catalogue
+	if err := json.Unmarshal(data, &cat); err != nil {
+		t.Fatal(err)
+	}
+	for arch := range compilers {
+		t.Run(arch, func(t *testing.T) {
+			var pkg = check(t, arch)
+			var values map[string]int64
+			if arch == runtime.GOARCH {
+				defs, err := readGodefs("", arch, &cat)
+				if err != nil {
+					t.Skip("cgo -godefs:", err)
+				}
+				if values, err = resolve(arch, defs, &cat); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, flagType := range cat.Types {
+				for _, c := range flagType.Constants {
+					object, ok := pkg.Scope().Lookup(c.Name).(*types.Const)
+					if !ok {
+						t.Errorf("%s is not declared", c.Name)
+						continue
+					}
+					if name := object.Type().(*types.Named).Obj().Name(); name != flagType.Type {
+						t.Errorf("%s has type %s, expected %s", c.Name, name, flagType.Type)
+					}
+					if value, ok := constant.Int64Val(object.Val()); values != nil && (!ok || value != values[c.Name]) {
+						t.Errorf("%s = %#x, expected %#x (%s)", c.Name, value, values[c.Name], c.Macro)
+					}
+				}
+			}
+		})
+	}
+}
+
+// check the linux package, as it would be compiled for arch.
+func check(t *testing.T, arch string) *types.Package {
+	var ctxt = build.Default
+	ctxt.GOARCH = arch
+	ctxt.CgoEnabled = false
+	pkg, err := ctxt.ImportDir("../..", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fset = token.NewFileSet()
+	var files []*ast.File
+	for _, name := range pkg.GoFiles {
+		file, err := parser.ParseFile(fset, filepath.Join(pkg.Dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, file)
+	}
+	var config = types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		Sizes:    types.SizesFor("gc", arch),
+		Error:    func(error) {}, // system calls for other architectures are missing from the host's syscall package.
+	}
+	checked, _ := config.Check(pkg.ImportPath, fset, files, nil)
+	return checked
+}
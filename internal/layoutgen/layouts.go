@@ -0,0 +1,26 @@
+// Code generated by "go run ./internal/layoutgen"; DO NOT EDIT.
+
+package main
+
+var layouts = map[string]map[string]layout{
+	"386": {
+		"stat":       {Size: 96, Align: 4, Fields: []field{{0, 8}, {8, 2}, {10, 2}, {12, 4}, {16, 4}, {20, 4}, {24, 4}, {28, 4}, {32, 8}, {40, 2}, {42, 2}, {44, 8}, {52, 4}, {56, 8}, {64, 8}, {72, 8}, {80, 8}, {88, 8}}}, // C.struct_stat
+		"timespec":   {Size: 8, Align: 4, Fields: []field{{0, 4}, {4, 4}}},                                                                                                                                                  // C.struct_timespec
+		"FileToPoll": {Size: 8, Align: 4, Fields: []field{{0, 4}, {4, 2}, {6, 2}}},                                                                                                                                          // C.struct_pollfd
+	},
+	"amd64": {
+		"stat":       {Size: 144, Align: 8, Fields: []field{{0, 8}, {8, 8}, {16, 8}, {24, 4}, {28, 4}, {32, 4}, {36, 4}, {40, 8}, {48, 8}, {56, 8}, {64, 8}, {72, 16}, {88, 16}, {104, 16}, {120, 24}}}, // C.struct_stat
+		"timespec":   {Size: 16, Align: 8, Fields: []field{{0, 8}, {8, 8}}},                                                                                                                             // C.struct_timespec
+		"FileToPoll": {Size: 8, Align: 4, Fields: []field{{0, 4}, {4, 2}, {6, 2}}},                                                                                                                      // C.struct_pollfd
+	},
+	"arm64": {
+		"stat":       {Size: 128, Align: 8, Fields: []field{{0, 8}, {8, 8}, {16, 4}, {20, 4}, {24, 4}, {28, 4}, {32, 8}, {40, 8}, {48, 8}, {56, 4}, {60, 4}, {64, 8}, {72, 16}, {88, 16}, {104, 16}, {120, 8}}}, // C.struct_stat
+		"timespec":   {Size: 16, Align: 8, Fields: []field{{0, 8}, {8, 8}}},                                                                                                                                     // C.struct_timespec
+		"FileToPoll": {Size: 8, Align: 4, Fields: []field{{0, 4}, {4, 2}, {6, 2}}},                                                                                                                              // C.struct_pollfd
+	},
+	"riscv64": {
+		"stat":       {Size: 128, Align: 8, Fields: []field{{0, 8}, {8, 8}, {16, 4}, {20, 4}, {24, 4}, {28, 4}, {32, 8}, {40, 8}, {48, 8}, {56, 4}, {60, 4}, {64, 8}, {72, 16}, {88, 16}, {104, 16}, {120, 8}}}, // C.struct_stat
+		"timespec":   {Size: 16, Align: 8, Fields: []field{{0, 8}, {8, 8}}},                                                                                                                                     // C.struct_timespec
+		"FileToPoll": {Size: 8, Align: 4, Fields: []field{{0, 4}, {4, 2}, {6, 2}}},                                                                                                                              // C.struct_pollfd
+	},
+}
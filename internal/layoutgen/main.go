@@ -0,0 +1,175 @@
+// Command layoutgen generates the expected layouts of the structs that mirror
+// the kernel's structs, for each supported architecture, from the C headers.
+//
+// The C structs are converted into Go with cgo -godefs, using the C compiler
+// for each architecture (CC_386, CC_amd64, CC_arm64, CC_riscv64 or a default
+// cross compiler), as cgo -godefs only compiles and does not run any code, the
+// layouts can be generated and then checked on any machine without emulation.
+// With -godefs, the output of cgo -godefs for an architecture can be read from
+// dir/arch.go instead.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// structs to generate, by the name of the Go type that mirrors them.
+var structs = []struct {
+	Go, C, Godefs string
+}{
+	{"stat", "struct_stat", "Stat"},
+	{"timespec", "struct_timespec", "Timespec"},
+	{"FileToPoll", "struct_pollfd", "PollFd"},
+}
+
+const headers = `
+#define _FILE_OFFSET_BITS 64
+#include <sys/stat.h>
+#include <time.h>
+#include <poll.h>
+`
+
+// compilers for each architecture, when CC_arch is not set.
+var compilers = map[string]string{
+	"386":     "i686-linux-gnu-gcc",
+	"amd64":   "x86_64-linux-gnu-gcc",
+	"arm64":   "aarch64-linux-gnu-gcc",
+	"riscv64": "riscv64-linux-gnu-gcc",
+}
+
+func main() {
+	var arches = flag.String("arch", "386,amd64,arm64,riscv64", "architectures to generate layouts for")
+	var godefs = flag.String("godefs", "", "directory to read the output of cgo -godefs from, for architectures with an arch.go file")
+	var output = flag.String("output", "internal/layoutgen/layouts.go", "file to write the layouts to")
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("layoutgen: ")
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by \"go run ./internal/layoutgen\"; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "package main\n\nvar layouts = map[string]map[string]layout{\n")
+	for _, arch := range strings.Split(*arches, ",") {
+		defs, err := readGodefs(*godefs, arch)
+		if err != nil {
+			log.Fatalf("%s: %v", arch, err)
+		}
+		pkg, err := check(arch, defs)
+		if err != nil {
+			log.Fatalf("%s: %v", arch, err)
+		}
+		fmt.Fprintf(&src, "\t%q: {\n", arch)
+		for _, s := range structs {
+			var object = pkg.Scope().Lookup(s.Godefs)
+			if object == nil {
+				log.Fatalf("%s: cgo -godefs is missing %s", arch, s.Godefs)
+			}
+			var l = layoutOf(types.SizesFor("gc", arch), object.Type())
+			fmt.Fprintf(&src, "\t\t%q: {Size: %d, Align: %d, Fields: []field{", s.Go, l.Size, l.Align)
+			for i, f := range l.Fields {
+				if i > 0 {
+					src.WriteString(", ")
+				}
+				fmt.Fprintf(&src, "{%d, %d}", f.Offset, f.Size)
+			}
+			fmt.Fprintf(&src, "}}, // C.%s\n", s.C)
+		}
+		fmt.Fprintf(&src, "\t},\n")
+	}
+	fmt.Fprintf(&src, "}\n")
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, formatted, 0666); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// readGodefs returns the structs converted by cgo -godefs for arch.
+func readGodefs(dir, arch string) ([]byte, error) {
+	if dir != "" {
+		defs, err := os.ReadFile(filepath.Join(dir, arch+".go"))
+		if err == nil || !os.IsNotExist(err) {
+			return defs, err
+		}
+	}
+	tmp, err := os.MkdirTemp("", "layoutgen")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	var input bytes.Buffer
+	fmt.Fprintf(&input, "package main\n\n/*%s*/\nimport \"C\"\n\n", headers)
+	for _, s := range structs {
+		fmt.Fprintf(&input, "type %s C.%s\n", s.Godefs, s.C)
+	}
+	if err := os.WriteFile(filepath.Join(tmp, "input.go"), input.Bytes(), 0666); err != nil {
+		return nil, err
+	}
+	var cc = os.Getenv("CC_" + arch)
+	if cc == "" {
+		cc = compilers[arch]
+		if arch == runtime.GOARCH {
+			cc = "cc"
+		}
+	}
+	var cmd = exec.Command("go", "tool", "cgo", "-godefs", "input.go")
+	cmd.Dir = tmp
+	cmd.Env = append(os.Environ(), "GOARCH="+arch, "CC="+cc)
+	cmd.Stderr = os.Stderr
+	return cmd.Output()
+}
+
+// check the Go source generated by cgo -godefs for arch.
+func check(arch string, src []byte) (*types.Package, error) {
+	var fset = token.NewFileSet()
+	file, err := parser.ParseFile(fset, arch+".go", src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+	var config = types.Config{Sizes: types.SizesFor("gc", arch)}
+	return config.Check("godefs", fset, []*ast.File{file}, nil)
+}
+
+// layout of a struct in memory.
+type layout struct {
+	Size, Align int64
+	Fields      []field // fields with a non-zero size.
+}
+
+type field struct {
+	Offset, Size int64
+}
+
+func layoutOf(sizes types.Sizes, t types.Type) layout {
+	var s = t.Underlying().(*types.Struct)
+	var vars []*types.Var
+	for i := range s.NumFields() {
+		vars = append(vars, s.Field(i))
+	}
+	var l = layout{Size: sizes.Sizeof(t), Align: sizes.Alignof(t)}
+	for i, offset := range sizes.Offsetsof(vars) {
+		if size := sizes.Sizeof(vars[i].Type()); size > 0 {
+			l.Fields = append(l.Fields, field{offset, size})
+		}
+	}
+	return l
+}
+
+func (l layout) equal(other layout) bool {
+	return l.Size == other.Size && l.Align == other.Align && slices.Equal(l.Fields, other.Fields)
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"testing"
+)
+
+// TestLayouts checks the layouts of the structs in the linux package, as they
+// would be compiled for each architecture, against the generated layouts.
+func TestLayouts(t *testing.T) {
+	for arch, expected := range layouts {
+		t.Run(arch, func(t *testing.T) {
+			var ctxt = build.Default
+			ctxt.GOARCH = arch
+			ctxt.CgoEnabled = false
+			pkg, err := ctxt.ImportDir("../..", 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var fset = token.NewFileSet()
+			var files []*ast.File
+			for _, name := range pkg.GoFiles {
+				file, err := parser.ParseFile(fset, filepath.Join(pkg.Dir, name), nil, parser.SkipObjectResolution)
+				if err != nil {
+					t.Fatal(err)
+				}
+				files = append(files, file)
+			}
+			var sizes = types.SizesFor("gc", arch)
+			var config = types.Config{
+				Importer: importer.ForCompiler(fset, "source", nil),
+				Sizes:    sizes,
+				Error: func(err error) {
+					if !foreign(arch, err) {
+						t.Error(err)
+					}
+				},
+			}
+			checked, _ := config.Check(pkg.ImportPath, fset, files, nil)
+			for name, want := range expected {
+				var object = checked.Scope().Lookup(name)
+				if object == nil {
+					t.Errorf("%s is missing", name)
+					continue
+				}
+				if got := layoutOf(sizes, object.Type()); !got.equal(want) {
+					t.Errorf("%s has layout\n%+v\nexpected\n%+v", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+// foreignSyscall is the error for a system call number that is missing from
+// the host's syscall package, such as SYS_MMAP2 when checking 386 on amd64.
+var foreignSyscall = regexp.MustCompile(`^undefined: syscall\.SYS_\w+$`)
+
+// foreign reports whether err is expected when checking the package for arch
+// with the syscall package of the host.
+func foreign(arch string, err error) bool {
+	terr, ok := err.(types.Error)
+	return ok && arch != runtime.GOARCH && foreignSyscall.MatchString(terr.Msg)
+}
+
+func TestForeign(t *testing.T) {
+	var other = "riscv64"
+	if runtime.GOARCH == other {
+		other = "amd64"
+	}
+	for _, test := range []struct {
+		arch    string
+		msg     string
+		foreign bool
+	}{
+		{other, "undefined: syscall.SYS_MMAP2", true},
+		{runtime.GOARCH, "undefined: syscall.SYS_MMAP2", false},
+		{other, "undefined: stat", false},
+		{other, "cannot use s (variable of type stat) as timespec value", false},
+	} {
+		if got := foreign(test.arch, types.Error{Msg: test.msg}); got != test.foreign {
+			t.Errorf("foreign(%s, %q) = %v, expected %v", test.arch, test.msg, got, test.foreign)
+		}
+	}
+}
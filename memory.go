@@ -0,0 +1,1271 @@
+package linux
+
+import (
+	"encoding/binary"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// Memory returns an [API] backed by an in-memory file system, suitable for
+// hermetic tests. The file system starts out with an empty root directory,
+// which is also the working directory that relative paths are resolved against.
+// Errors are reported with the same types as [Native]. Memory mapped files are
+// backed by byte slices, [MapShared] mappings share their bytes with the file,
+// as long as the file is not grown. [ClockMonotonic] and [ClockBoottime]
+// measure the time since the API was created, unlike those of [Native], which
+// usually count from boot, see [API.ClockTime]. The CPU time clocks and
+// [ClockAtomicTime] are not supported.
+func Memory() *API {
+	var mem = newMemory()
+	var api = new(API)
+	*api = API{
+		Read:  mem.read,
+		Write: mem.write,
+		Open: func(name Path, mode FileAccessMode, flag FileCreationFlags, status FileStatusFlags, perm FilePermissions) (File, error) {
+			fd, err := mem.openFile(name, mode, flag, status, perm)
+			return File{Linux: api, Descriptor: fd}, err
+		},
+		Close:           mem.close,
+		Stat:            mem.stat,
+		StatFile:        mem.statFile,
+		StatLink:        mem.stat,
+		Poll:            mem.poll,
+		Seek:            mem.seek,
+		MapIntoMemory:   mem.mapIntoMemory,
+		ProtectMemory:   mem.protectMemory,
+		Heap:            mem.heap,
+		ReadDirectory:   mem.readDirectory,
+		MakeDirectory:   mem.makeDirectory,
+		RemoveFile:      mem.removeFile,
+		RemoveDirectory: mem.removeDirectory,
+		Rename:          mem.rename,
+		LinkFile:        mem.linkFile,
+		Sync:            mem.sync,
+		CreateEvent: func(initial uint32, flags EventFlags) (File, error) {
+			fd, err := mem.createEvent(initial, flags)
+			return File{Linux: api, Descriptor: fd}, err
+		},
+		Remap: func(addr unsafe.Pointer, length, newLength int, flags Remap, newAddr unsafe.Pointer) (unsafe.Pointer, error) {
+			mapped, err := mem.remap(addr, length, newLength, flags, newAddr)
+			if err != nil {
+				return nil, err
+			}
+			return mapped.UnsafePointer(), nil
+		},
+		AdviseMemory:    mem.adviseMemory,
+		LockMemory:      mem.lockMemory,
+		UnlockMemory:    mem.unlockMemory,
+		MemoryResidency: mem.memoryResidency,
+		SyncMemory:      mem.syncMemory,
+		CreateMemoryFile: func(name Path, flags MemoryFileFlags) (File, error) {
+			fd, err := mem.createMemoryFile(name, flags)
+			return File{Linux: api, Descriptor: fd}, err
+		},
+		Truncate:        mem.truncate,
+		WaitFutex:       mem.waitFutex,
+		WaitFutexBitset: mem.waitFutexBitset,
+		WakeFutex: func(addr *uint32, count int, flags FutexFlags) (int, error) {
+			return mem.wakeFutexBitset(addr, count, futexBitsetAny, flags)
+		},
+		WakeFutexBitset: mem.wakeFutexBitset,
+		RequeueFutex:    mem.requeueFutex,
+		WaitFutexes:     mem.waitFutexesWithDeadline,
+		ClockTime:       mem.clockTime,
+		ClockResolution: mem.clockResolution,
+		Sleep:           mem.sleep,
+	}
+	return api
+}
+
+func newMemory() *memory {
+	var mem = &memory{
+		files:   make(map[Path]*memoryFile),
+		open:    make(map[FileDescriptor]*memoryOpen),
+		maps:    make(map[unsafe.Pointer]*memoryMap),
+		next:    3,
+		started: time.Now(),
+	}
+	mem.changed = sync.NewCond(&mem.mu)
+	mem.files["/"] = mem.node(FilePermissions(FileTypeDirectory) | 0755)
+	return mem
+}
+
+// memoryBlockSize is the granularity at which [Memory] tracks the data and holes
+// of sparse files.
+const memoryBlockSize = 4096
+
+type memory struct {
+	mu    sync.Mutex
+	files map[Path]*memoryFile
+	open  map[FileDescriptor]*memoryOpen
+	maps  map[unsafe.Pointer]*memoryMap
+	next  FileDescriptor
+	nodes IndexNode
+	brk   []byte
+	end   int
+
+	futexes map[*uint32][]memoryFutex // waiters on each futex.
+	started time.Time                 // start of the monotonic clock.
+	changed *sync.Cond                // broadcast when the readiness of a file changes, see [memory.poll].
+}
+
+// memoryFile is an index node of the in-memory file system.
+type memoryFile struct {
+	header FileHeader
+	data   []byte
+	blocks map[int64]bool // blocks that have been written to, the rest are holes.
+	event  *memoryEvent   // counter of the file, if created with [API.CreateEvent].
+}
+
+// memoryEvent is the counter of an event, see [API.CreateEvent].
+type memoryEvent struct {
+	count     uint64
+	semaphore bool
+}
+
+// memoryEventMax is the largest value that an event counter can hold.
+const memoryEventMax = 1<<64 - 2
+
+// memoryOpen is an open file description.
+type memoryOpen struct {
+	file   *memoryFile
+	name   Path // path the file was opened at, to list directories.
+	mode   FileAccessMode
+	status FileStatusFlags
+	offset int64
+	last   Path // name of the last directory entry read, after "." and "..".
+}
+
+type memoryMap struct {
+	prot  MemoryProtection
+	slice []byte
+	mem   *memory
+}
+
+func (mem *memory) now() Time {
+	return TimeFrom(time.Now())
+}
+
+func (mem *memory) node(perm FilePermissions) *memoryFile {
+	mem.nodes++
+	var now = mem.now()
+	return &memoryFile{
+		header: FileHeader{
+			Device:             1,
+			IndexNode:          mem.nodes,
+			HardLinks:          1,
+			Permissions:        perm,
+			User:               UserID(syscall.Getuid()),
+			Group:              GroupID(syscall.Getgid()),
+			BlockSize:          memoryBlockSize,
+			AccessedAt:         now,
+			ModifiedAt:         now,
+			ModifiedMetadataAt: now,
+		},
+		blocks: make(map[int64]bool),
+	}
+}
+
+func (file *memoryFile) isDirectory() bool {
+	return file.header.IsDirectory()
+}
+
+// lookup resolves name to a file, the file is nil if the name does not exist
+// within an existing directory.
+func (mem *memory) lookup(name Path) (Path, *memoryFile, syscall.Errno) {
+	if name == "" {
+		return name, nil, syscall.ENOENT
+	}
+	var clean = Path(path.Clean("/" + string(name)))
+	for dir := path.Dir(string(clean)); ; dir = path.Dir(dir) {
+		parent, ok := mem.files[Path(dir)]
+		if !ok {
+			return clean, nil, syscall.ENOENT
+		}
+		if !parent.isDirectory() {
+			return clean, nil, syscall.ENOTDIR
+		}
+		if parent.header.Permissions&FileExecutableByUser == 0 {
+			return clean, nil, syscall.EACCES
+		}
+		if dir == "/" {
+			break
+		}
+	}
+	return clean, mem.files[clean], 0
+}
+
+func (mem *memory) descriptor(fd FileDescriptor) (*memoryOpen, syscall.Errno) {
+	open, ok := mem.open[fd]
+	if !ok {
+		return nil, syscall.EBADF
+	}
+	return open, 0
+}
+
+func (mem *memory) openFile(name Path, mode FileAccessMode, flag FileCreationFlags, status FileStatusFlags, perm FilePermissions) (FileDescriptor, error) {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	clean, file, errno := mem.lookup(name)
+	if errno != 0 {
+		return -1, new(OpenError).parse(errno)
+	}
+	switch {
+	case flag&FileTemporaryInside == FileTemporaryInside:
+		if file == nil {
+			return -1, new(OpenError).parse(syscall.ENOENT)
+		}
+		if !file.isDirectory() {
+			return -1, new(OpenError).parse(syscall.ENOTDIR)
+		}
+		if mode == FileAccessReadOnly {
+			return -1, new(OpenError).parse(syscall.EINVAL)
+		}
+		file = mem.node(FilePermissions(FileTypeRegular) | perm&^fileTypeMask)
+		file.header.HardLinks = 0
+	case file == nil:
+		if flag&FileCreateIfNeeded == 0 {
+			return -1, new(OpenError).parse(syscall.ENOENT)
+		}
+		parent := mem.files[Path(path.Dir(string(clean)))]
+		if parent.header.Permissions&FileWritableByUser == 0 {
+			return -1, new(OpenError).parse(syscall.EACCES)
+		}
+		file = mem.node(FilePermissions(FileTypeRegular) | perm&^fileTypeMask)
+		mem.files[clean] = file
+	default:
+		if flag&(FileCreateIfNeeded|FileAssertCreation) == FileCreateIfNeeded|FileAssertCreation {
+			return -1, new(OpenError).parse(syscall.EEXIST)
+		}
+		if flag&FileAssertDirectory != 0 && !file.isDirectory() {
+			return -1, new(OpenError).parse(syscall.ENOTDIR)
+		}
+		if file.isDirectory() && mode != FileAccessReadOnly {
+			return -1, new(OpenError).parse(syscall.EISDIR)
+		}
+		if status&FilePath == 0 {
+			if mode != FileAccessWriteOnly && file.header.Permissions&FileReadableByUser == 0 {
+				return -1, new(OpenError).parse(syscall.EACCES)
+			}
+			if mode != FileAccessReadOnly && file.header.Permissions&FileWritableByUser == 0 {
+				return -1, new(OpenError).parse(syscall.EACCES)
+			}
+		}
+		if flag&FileTruncatedToZero != 0 && mode != FileAccessReadOnly {
+			file.data = nil
+			file.blocks = make(map[int64]bool)
+			file.header.Size = 0
+			file.header.BlockCount = 0
+			file.header.ModifiedAt = mem.now()
+		}
+	}
+	var fd = mem.next
+	for mem.open[fd] != nil {
+		fd++
+	}
+	mem.next = fd + 1
+	mem.open[fd] = &memoryOpen{file: file, name: clean, mode: mode, status: status}
+	return fd, nil
+}
+
+func (mem *memory) read(fd FileDescriptor, buf []byte) (Bytes, error) {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	open, errno := mem.descriptor(fd)
+	if errno != 0 {
+		return 0, new(ReadError).parse(errno)
+	}
+	if open.mode == FileAccessWriteOnly || open.status&FilePath != 0 {
+		return 0, new(ReadError).parse(syscall.EBADF)
+	}
+	if open.file.isDirectory() {
+		return 0, new(ReadError).parse(syscall.EISDIR)
+	}
+	if event := open.file.event; event != nil {
+		mem.changed.Broadcast()
+		return event.read(buf)
+	}
+	if open.offset >= open.file.header.Size {
+		return 0, nil
+	}
+	var n = copy(buf[:min(int64(len(buf)), MaxRead)], open.file.data[open.offset:open.file.header.Size])
+	open.offset += int64(n)
+	if open.status&FileDoNotUpdateAccessTime == 0 {
+		open.file.header.AccessedAt = mem.now()
+	}
+	return Bytes(n), nil
+}
+
+func (mem *memory) write(fd FileDescriptor, buf []byte) (Bytes, error) {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	open, errno := mem.descriptor(fd)
+	if errno != 0 {
+		return 0, new(WriteError).parse(errno)
+	}
+	if open.mode == FileAccessReadOnly || open.status&FilePath != 0 {
+		return 0, new(WriteError).parse(syscall.EBADF)
+	}
+	var file = open.file
+	if file.event != nil {
+		mem.changed.Broadcast()
+		return file.event.write(buf)
+	}
+	if open.status&FileAppend != 0 {
+		open.offset = file.header.Size
+	}
+	var end = open.offset + int64(len(buf))
+	if end > int64(len(file.data)) {
+		file.data = append(file.data, make([]byte, end-int64(len(file.data)))...)
+	}
+	copy(file.data[open.offset:], buf)
+	for block := open.offset / memoryBlockSize; block*memoryBlockSize < end; block++ {
+		file.blocks[block] = true
+	}
+	open.offset = end
+	file.header.Size = max(file.header.Size, end)
+	file.header.BlockCount = int64(len(file.blocks)) * memoryBlockSize / 512
+	file.header.ModifiedAt = mem.now()
+	file.header.ModifiedMetadataAt = file.header.ModifiedAt
+	return Bytes(len(buf)), nil
+}
+
+func (mem *memory) close(fd FileDescriptor) error {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	if _, errno := mem.descriptor(fd); errno != 0 {
+		return new(CloseError).parse(errno)
+	}
+	delete(mem.open, fd)
+	mem.next = min(mem.next, fd)
+	mem.changed.Broadcast()
+	return nil
+}
+
+func (mem *memory) stat(name Path) (FileHeader, error) {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	_, file, errno := mem.lookup(name)
+	if errno == 0 && file == nil {
+		errno = syscall.ENOENT
+	}
+	if errno != 0 {
+		return FileHeader{}, new(StatError).parse(errno)
+	}
+	return file.header, nil
+}
+
+func (mem *memory) statFile(fd FileDescriptor) (FileHeader, error) {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	open, errno := mem.descriptor(fd)
+	if errno != 0 {
+		return FileHeader{}, new(StatError).parse(errno)
+	}
+	return open.file.header, nil
+}
+
+// poll reports every open file as ready for reading and writing, as is the case
+// for regular files on Linux, except for events, which are only ready for
+// reading once signalled. Until a file is ready, poll waits for the readiness
+// of the files to change or for the timeout to expire.
+func (mem *memory) poll(files []FileToPoll, timeout time.Duration) (int, error) {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	var expired = timeout == 0
+	if timeout > 0 {
+		var timer = time.AfterFunc(timeout, func() {
+			mem.mu.Lock()
+			defer mem.mu.Unlock()
+			expired = true
+			mem.changed.Broadcast()
+		})
+		defer timer.Stop()
+	}
+	for {
+		if ready := mem.ready(files); ready > 0 || expired {
+			return ready, nil
+		}
+		mem.changed.Wait()
+	}
+}
+
+// ready sets the results of the files to poll, returns how many have any.
+func (mem *memory) ready(files []FileToPoll) int {
+	var ready int
+	for i := range files {
+		files[i].Result = 0
+		if files[i].File < 0 {
+			continue
+		}
+		if open, errno := mem.descriptor(files[i].File); errno != 0 {
+			files[i].Result = PollHasInvalidRequest
+		} else if event := open.file.event; event != nil {
+			if event.count > 0 {
+				files[i].Result |= files[i].Notify & PollHasReadAvailable
+			}
+			if event.count < memoryEventMax {
+				files[i].Result |= files[i].Notify & PollHasWriteAvailable
+			}
+		} else {
+			files[i].Result = files[i].Notify & (PollHasReadAvailable | PollHasWriteAvailable)
+		}
+		if files[i].Result != 0 {
+			ready++
+		}
+	}
+	return ready
+}
+
+func (mem *memory) seek(fd FileDescriptor, offset int64, whence Seek) (int64, error) {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	open, errno := mem.descriptor(fd)
+	if errno != 0 {
+		return -1, new(SeekError).parse(errno)
+	}
+	var file = open.file
+	var size = file.header.Size
+	switch whence {
+	case SeekRelative:
+		offset += open.offset
+	case SeekRelativeToEnd:
+		offset += size
+	case SeekData, SeekHole:
+		if offset < 0 {
+			return -1, new(SeekError).parse(syscall.EINVAL)
+		}
+		if offset >= size {
+			return -1, new(SeekError).parse(syscall.ENXIO)
+		}
+		for ; offset < size; offset = (offset/memoryBlockSize + 1) * memoryBlockSize {
+			if file.blocks[offset/memoryBlockSize] == (whence == SeekData) {
+				break
+			}
+		}
+		if offset >= size {
+			if whence == SeekData {
+				return -1, new(SeekError).parse(syscall.ENXIO)
+			}
+			offset = size
+		}
+	case SeekRelativeToStart:
+	default:
+		return -1, new(SeekError).parse(syscall.EINVAL)
+	}
+	if offset < 0 {
+		return -1, new(SeekError).parse(syscall.EINVAL)
+	}
+	open.offset = offset
+	return offset, nil
+}
+
+// readDirectory lists "." and ".." followed by the entries of the directory in
+// order of their names, resuming after the last entry read, so that entries
+// can be added and removed while the directory is being read.
+func (mem *memory) readDirectory(fd FileDescriptor, buf []byte) (Bytes, error) {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	open, errno := mem.descriptor(fd)
+	if errno != 0 {
+		return 0, new(ReadDirectoryError).parse(errno)
+	}
+	if open.status&FilePath != 0 {
+		return 0, new(ReadDirectoryError).parse(syscall.EBADF)
+	}
+	if !open.file.isDirectory() {
+		return 0, new(ReadDirectoryError).parse(syscall.ENOTDIR)
+	}
+	if mem.files[open.name] != open.file {
+		return 0, new(ReadDirectoryError).parse(syscall.ENOENT)
+	}
+	if open.offset < 2 {
+		open.last = ""
+	}
+	var parent = mem.files[Path(path.Dir(string(open.name)))]
+	var entries []DirectoryEntry
+	if open.offset < 1 {
+		entries = append(entries, DirectoryEntry{open.file.header.IndexNode, FileTypeDirectory, "."})
+	}
+	if open.offset < 2 {
+		entries = append(entries, DirectoryEntry{parent.header.IndexNode, FileTypeDirectory, ".."})
+	}
+	var children []DirectoryEntry
+	for name, file := range mem.files {
+		if name != "/" && Path(path.Dir(string(name))) == open.name && Path(path.Base(string(name))) > open.last {
+			children = append(children, DirectoryEntry{file.header.IndexNode, file.header.Type(), Path(path.Base(string(name)))})
+		}
+	}
+	slices.SortFunc(children, func(a, b DirectoryEntry) int { return strings.Compare(string(a.Name), string(b.Name)) })
+	var out = buf[:0:len(buf)]
+	for _, entry := range append(entries, children...) {
+		if len(out)+encodedDirectorySize(entry) > len(buf) {
+			if len(out) == 0 {
+				return 0, new(ReadDirectoryError).parse(syscall.EINVAL)
+			}
+			break
+		}
+		out = encodeDirectory(out, entry, open.offset+1)
+		if open.offset < 2 {
+			open.offset++
+		} else {
+			open.last = entry.Name
+		}
+	}
+	if open.status&FileDoNotUpdateAccessTime == 0 {
+		open.file.header.AccessedAt = mem.now()
+	}
+	return Bytes(len(out)), nil
+}
+
+// parentWritable checks that the directory containing clean can be modified.
+func (mem *memory) parentWritable(clean Path) syscall.Errno {
+	if mem.files[Path(path.Dir(string(clean)))].header.Permissions&FileWritableByUser == 0 {
+		return syscall.EACCES
+	}
+	return 0
+}
+
+// subtree returns the files at root and below it.
+func (mem *memory) subtree(root Path) map[Path]*memoryFile {
+	var files = make(map[Path]*memoryFile)
+	for name, file := range mem.files {
+		if name == root || strings.HasPrefix(string(name), string(root)+"/") {
+			files[name] = file
+		}
+	}
+	return files
+}
+
+func (mem *memory) makeDirectory(name Path, perm FilePermissions) error {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	clean, file, errno := mem.lookup(name)
+	if errno == 0 && file != nil {
+		errno = syscall.EEXIST
+	}
+	if errno == 0 {
+		errno = mem.parentWritable(clean)
+	}
+	if errno != 0 {
+		return new(MakeDirectoryError).parse(errno)
+	}
+	mem.files[clean] = mem.node(FilePermissions(FileTypeDirectory) | perm&^fileTypeMask)
+	return nil
+}
+
+func (mem *memory) removeFile(name Path) error {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	clean, file, errno := mem.lookup(name)
+	switch {
+	case errno != 0:
+	case file == nil:
+		errno = syscall.ENOENT
+	case file.isDirectory():
+		errno = syscall.EISDIR
+	default:
+		errno = mem.parentWritable(clean)
+	}
+	if errno != 0 {
+		return new(RemoveError).parse(errno)
+	}
+	delete(mem.files, clean)
+	file.header.HardLinks--
+	file.header.ModifiedMetadataAt = mem.now()
+	return nil
+}
+
+func (mem *memory) removeDirectory(name Path) error {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	clean, file, errno := mem.lookup(name)
+	switch {
+	case errno != 0:
+	case file == nil:
+		errno = syscall.ENOENT
+	case !file.isDirectory():
+		errno = syscall.ENOTDIR
+	case clean == "/":
+		errno = syscall.EBUSY
+	case len(mem.subtree(clean)) > 1:
+		errno = syscall.ENOTEMPTY
+	default:
+		errno = mem.parentWritable(clean)
+	}
+	if errno != 0 {
+		return new(RemoveError).parse(errno)
+	}
+	delete(mem.files, clean)
+	return nil
+}
+
+// rename moves the subtree at from to to, along with the names of the open
+// files within it, [RenameExchange] moves both subtrees.
+func (mem *memory) rename(from, to Path, flags Rename) error {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	var rename = func() syscall.Errno {
+		if flags&^(RenameDoNotReplace|RenameExchange) != 0 || flags == RenameDoNotReplace|RenameExchange {
+			return syscall.EINVAL
+		}
+		src, srcFile, errno := mem.lookup(from)
+		if errno != 0 {
+			return errno
+		}
+		dst, dstFile, errno := mem.lookup(to)
+		switch {
+		case errno != 0:
+			return errno
+		case srcFile == nil:
+			return syscall.ENOENT
+		case src == "/" || dst == "/":
+			return syscall.EBUSY
+		case src == dst:
+			return 0
+		case strings.HasPrefix(string(dst), string(src)+"/"):
+			return syscall.EINVAL
+		}
+		if errno := mem.parentWritable(src); errno != 0 {
+			return errno
+		}
+		if errno := mem.parentWritable(dst); errno != 0 {
+			return errno
+		}
+		var moves = map[Path]Path{src: dst}
+		switch {
+		case flags&RenameExchange != 0:
+			if dstFile == nil {
+				return syscall.ENOENT
+			}
+			if strings.HasPrefix(string(src), string(dst)+"/") {
+				return syscall.EINVAL
+			}
+			moves[dst] = src
+		case dstFile != nil:
+			switch {
+			case flags&RenameDoNotReplace != 0:
+				return syscall.EEXIST
+			case srcFile.isDirectory() && !dstFile.isDirectory():
+				return syscall.ENOTDIR
+			case !srcFile.isDirectory() && dstFile.isDirectory():
+				return syscall.EISDIR
+			case len(mem.subtree(dst)) > 1:
+				return syscall.ENOTEMPTY
+			}
+			delete(mem.files, dst)
+			dstFile.header.HardLinks--
+		}
+		var moved = make(map[Path]*memoryFile)
+		for from, to := range moves {
+			for name, file := range mem.subtree(from) {
+				delete(mem.files, name)
+				moved[to+name[len(from):]] = file
+			}
+			for _, open := range mem.open {
+				if open.name == from || strings.HasPrefix(string(open.name), string(from)+"/") {
+					open.name = to + open.name[len(from):]
+				}
+			}
+		}
+		for name, file := range moved {
+			mem.files[name] = file
+		}
+		srcFile.header.ModifiedMetadataAt = mem.now()
+		return 0
+	}
+	return new(RenameError).parse(rename())
+}
+
+func (mem *memory) linkFile(fd FileDescriptor, name Path) error {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	open, errno := mem.descriptor(fd)
+	if errno != 0 {
+		return new(LinkError).parse(errno)
+	}
+	clean, file, errno := mem.lookup(name)
+	switch {
+	case errno != 0:
+	case file != nil:
+		errno = syscall.EEXIST
+	case open.file.isDirectory():
+		errno = syscall.EPERM
+	default:
+		errno = mem.parentWritable(clean)
+	}
+	if errno != 0 {
+		return new(LinkError).parse(errno)
+	}
+	mem.files[clean] = open.file
+	open.file.header.HardLinks++
+	open.file.header.ModifiedMetadataAt = mem.now()
+	return nil
+}
+
+// createEvent opens an anonymous file with an event counter, reads of an
+// unsignalled event fail with [ReadError.WouldBlock], as Memory never blocks.
+func (mem *memory) createEvent(initial uint32, flags EventFlags) (FileDescriptor, error) {
+	if flags&^(EventCloseOnExecute|EventNonBlocking|EventSemaphore) != 0 {
+		return -1, new(EventError).Types().Invalid
+	}
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	var file = mem.node(0600)
+	file.event = &memoryEvent{count: uint64(initial), semaphore: flags&EventSemaphore != 0}
+	var fd = mem.next
+	for mem.open[fd] != nil {
+		fd++
+	}
+	mem.next = fd + 1
+	mem.open[fd] = &memoryOpen{file: file, mode: FileAccessReadWrite, status: FileStatusFlags(flags & EventNonBlocking)}
+	return fd, nil
+}
+
+// createMemoryFile opens an anonymous regular file.
+func (mem *memory) createMemoryFile(name Path, flags MemoryFileFlags) (FileDescriptor, error) {
+	if flags&^(MemoryFileCloseOnExecute|MemoryFileAllowSealing|MemoryFileHugeTables) != 0 || len(name) > 249 {
+		return -1, new(MemoryFileError).Types().Invalid
+	}
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	var file = mem.node(FilePermissions(FileTypeRegular) | 0777)
+	file.header.HardLinks = 0
+	var fd = mem.next
+	for mem.open[fd] != nil {
+		fd++
+	}
+	mem.next = fd + 1
+	mem.open[fd] = &memoryOpen{file: file, name: "/memfd:" + name, mode: FileAccessReadWrite}
+	return fd, nil
+}
+
+func (mem *memory) truncate(fd FileDescriptor, size int64) error {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	open, errno := mem.descriptor(fd)
+	if errno != 0 {
+		return new(TruncateError).parse(errno)
+	}
+	if open.mode == FileAccessReadOnly || open.status&FilePath != 0 {
+		return new(TruncateError).Types().BadFile
+	}
+	var file = open.file
+	if size < 0 || file.isDirectory() || file.event != nil {
+		return new(TruncateError).Types().Invalid
+	}
+	if size > int64(len(file.data)) {
+		file.data = append(file.data, make([]byte, size-int64(len(file.data)))...)
+	}
+	clear(file.data[size:])
+	for block := range file.blocks {
+		if block*memoryBlockSize >= size {
+			delete(file.blocks, block)
+		}
+	}
+	file.header.Size = size
+	file.header.BlockCount = int64(len(file.blocks)) * memoryBlockSize / 512
+	file.header.ModifiedAt = mem.now()
+	file.header.ModifiedMetadataAt = file.header.ModifiedAt
+	return nil
+}
+
+func (event *memoryEvent) read(buf []byte) (Bytes, error) {
+	if len(buf) < 8 {
+		return 0, new(ReadError).Types().Invalid
+	}
+	if event.count == 0 {
+		return 0, new(ReadError).Types().WouldBlock
+	}
+	var value = event.count
+	if event.semaphore {
+		value = 1
+	}
+	event.count -= value
+	binary.NativeEndian.PutUint64(buf, value)
+	return 8, nil
+}
+
+func (event *memoryEvent) write(buf []byte) (Bytes, error) {
+	if len(buf) < 8 {
+		return 0, new(WriteError).Types().Invalid
+	}
+	var value = binary.NativeEndian.Uint64(buf)
+	if value > memoryEventMax {
+		return 0, new(WriteError).Types().Invalid
+	}
+	if value > memoryEventMax-event.count {
+		return 0, new(WriteError).Types().WouldBlock
+	}
+	event.count += value
+	return 8, nil
+}
+
+// sync has nothing to flush, files are only held in memory.
+func (mem *memory) sync(fd FileDescriptor) error {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	_, errno := mem.descriptor(fd)
+	return new(SyncError).parse(errno)
+}
+
+func (mem *memory) mapIntoMemory(addr unsafe.Pointer, length int, prot MemoryProtection, mtype MapType, flags Map, fd FileDescriptor, offset uintptr) (MappedMemory, error) {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	if length <= 0 || offset%memoryBlockSize != 0 || mtype&(MapShared|MapPrivate) == 0 {
+		return nil, new(MapError).parse(syscall.EINVAL)
+	}
+	var slice []byte
+	if flags&MapAnonymous != 0 {
+		slice = make([]byte, length)
+	} else {
+		open, errno := mem.descriptor(fd)
+		if errno != 0 {
+			return nil, new(MapError).parse(errno)
+		}
+		if open.file.isDirectory() {
+			return nil, new(MapError).parse(syscall.ENODEV)
+		}
+		if open.mode == FileAccessWriteOnly || (mtype&MapShared != 0 && prot&MemoryAllowWrites != 0 && open.mode != FileAccessReadWrite) {
+			return nil, new(MapError).parse(syscall.EACCES)
+		}
+		var file = open.file
+		var end = int64(offset) + int64(length)
+		if end > int64(len(file.data)) {
+			file.data = append(file.data, make([]byte, end-int64(len(file.data)))...)
+		}
+		slice = file.data[offset:end:end]
+		if mtype&MapShared == 0 {
+			slice = append([]byte(nil), slice...)
+		}
+	}
+	var mapped = &memoryMap{prot: prot, slice: slice, mem: mem}
+	mem.maps[unsafe.Pointer(&slice[0])] = mapped
+	return mapped, nil
+}
+
+func (mem *memory) protectMemory(addr unsafe.Pointer, length int, prot MemoryProtection) error {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	mapped, ok := mem.maps[addr]
+	if !ok || length > len(mapped.slice) {
+		return new(ProtectMemoryError).parse(syscall.ENOMEM)
+	}
+	mapped.prot = prot
+	return nil
+}
+
+// heap simulates a program break within a fixed size arena.
+func (mem *memory) heap(addr unsafe.Pointer) (unsafe.Pointer, error) {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	if mem.brk == nil {
+		mem.brk = make([]byte, 1<<20)
+	}
+	var start = unsafe.Pointer(unsafe.SliceData(mem.brk))
+	if addr != nil {
+		var end = uintptr(addr) - uintptr(start)
+		if uintptr(addr) < uintptr(start) || end > uintptr(len(mem.brk)) {
+			return nil, new(HeapError).parse(syscall.ENOMEM)
+		}
+		mem.end = int(end)
+	}
+	return unsafe.Add(start, mem.end), nil
+}
+
+// mapped returns the mapping that starts at addr and spans length bytes.
+func (mem *memory) mapped(addr unsafe.Pointer, length int) (*memoryMap, syscall.Errno) {
+	mapped, ok := mem.maps[addr]
+	if !ok || length > len(mapped.slice) {
+		return nil, syscall.ENOMEM
+	}
+	if length <= 0 {
+		return nil, syscall.EINVAL
+	}
+	return mapped, 0
+}
+
+// remap resizes the mapping in place when it shrinks, otherwise the mapping
+// is moved to a copy, which no longer shares its bytes with the file.
+func (mem *memory) remap(addr unsafe.Pointer, length, newLength int, flags Remap, newAddr unsafe.Pointer) (*memoryMap, error) {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	mapped, errno := mem.mapped(addr, length)
+	if errno != 0 {
+		return nil, new(RemapError).parse(errno)
+	}
+	if newLength <= 0 || flags&^(RemapMayMove|RemapExactAddress|RemapDoNotUnmap) != 0 ||
+		flags&(RemapExactAddress|RemapDoNotUnmap) != 0 && flags&RemapMayMove == 0 {
+		return nil, new(RemapError).Types().Invalid
+	}
+	if flags&RemapExactAddress != 0 {
+		return nil, new(RemapError).Types().Invalid // addresses cannot be chosen in memory.
+	}
+	if newLength <= len(mapped.slice) && flags&RemapDoNotUnmap == 0 {
+		mapped.slice = mapped.slice[:newLength:newLength]
+		return mapped, nil
+	}
+	if flags&RemapMayMove == 0 {
+		return nil, new(RemapError).Types().OutOfMemory
+	}
+	var slice = make([]byte, newLength)
+	copy(slice, mapped.slice)
+	if flags&RemapDoNotUnmap != 0 {
+		clear(mapped.slice)
+		mapped = &memoryMap{prot: mapped.prot, mem: mem}
+	} else {
+		delete(mem.maps, addr)
+	}
+	mapped.slice = slice
+	mem.maps[mapped.UnsafePointer()] = mapped
+	return mapped, nil
+}
+
+// adviseMemory has no effect, as memory keeps all of its pages resident.
+func (mem *memory) adviseMemory(addr unsafe.Pointer, length int, advice MemoryAdvice) error {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	if _, errno := mem.mapped(addr, length); errno != 0 {
+		return new(AdviseMemoryError).parse(errno)
+	}
+	if !slices.ContainsFunc(memoryAdviceNames, func(name flagName[MemoryAdvice]) bool { return name.value == advice }) {
+		return new(AdviseMemoryError).Types().Invalid
+	}
+	return nil
+}
+
+func (mem *memory) lockMemory(addr unsafe.Pointer, length int, flags MemoryLock) error {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	if _, errno := mem.mapped(addr, length); errno != 0 {
+		return new(LockMemoryError).parse(errno)
+	}
+	if flags&^MemoryLockOnFault != 0 {
+		return new(LockMemoryError).Types().Invalid
+	}
+	return nil
+}
+
+func (mem *memory) unlockMemory(addr unsafe.Pointer, length int) error {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	_, errno := mem.mapped(addr, length)
+	return new(LockMemoryError).parse(errno)
+}
+
+// memoryResidency reports every page as resident.
+func (mem *memory) memoryResidency(addr unsafe.Pointer, length int, pages []byte) error {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	if _, errno := mem.mapped(addr, length); errno != 0 {
+		return new(MemoryResidencyError).parse(errno)
+	}
+	var page = syscall.Getpagesize()
+	var count = (length + page - 1) / page
+	if len(pages) < count {
+		return new(MemoryResidencyError).Types().Invalid
+	}
+	for i := range count {
+		pages[i] = 1
+	}
+	return nil
+}
+
+// syncMemory has no effect, as shared mappings share their bytes with the file.
+func (mem *memory) syncMemory(addr unsafe.Pointer, length int, flags MemorySync) error {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	if _, errno := mem.mapped(addr, length); errno != 0 {
+		return new(SyncMemoryError).parse(errno)
+	}
+	if flags&^(MemorySyncAsynchronously|MemorySyncInvalidate|MemorySyncSynchronously) != 0 ||
+		flags&(MemorySyncAsynchronously|MemorySyncSynchronously) == MemorySyncAsynchronously|MemorySyncSynchronously {
+		return new(SyncMemoryError).Types().Invalid
+	}
+	return nil
+}
+
+// memoryFutex is a waiter queued on a futex, see [API.WaitFutex].
+type memoryFutex struct {
+	waiter *memoryWaiter
+	index  int // of the futex in the call to [API.WaitFutexes].
+	bitset uint32
+}
+
+// memoryWaiter is a call waiting on one or more futexes.
+type memoryWaiter struct {
+	woken   chan int  // receives the index of the futex that woke the waiter.
+	done    bool      // woken or timed out.
+	futexes []*uint32 // that the waiter is queued on, by index, as requeued.
+}
+
+// futexTimeout converts the timeout of a futex operation into a duration,
+// which is negative for no timeout.
+func (mem *memory) futexTimeout(timeout *Time, absolute bool, flags FutexFlags) (time.Duration, syscall.Errno) {
+	if timeout == nil {
+		return -1, 0
+	}
+	if timeout.Seconds < 0 || timeout.Nanos < 0 || timeout.Nanos >= 1e9 {
+		return 0, syscall.EINVAL
+	}
+	switch {
+	case !absolute:
+		return timeout.AsDuration(), 0
+	case flags&FutexRealtimeClock != 0:
+		return max(time.Until(timeout.AsTime()), 0), 0
+	default:
+		return max(timeout.AsDuration()-time.Since(mem.started), 0), 0
+	}
+}
+
+// waitFutexes queues a waiter on each of the futexes, as long as they hold
+// their values, and waits until one of them is woken or the timeout expires.
+func (mem *memory) waitFutexes(futexes []FutexToWait, bitset uint32, timeout time.Duration) (int, syscall.Errno) {
+	var waiter = &memoryWaiter{woken: make(chan int, 1), futexes: make([]*uint32, len(futexes))}
+	mem.mu.Lock()
+	for _, futex := range futexes {
+		if futex.Futex == nil {
+			mem.mu.Unlock()
+			return -1, syscall.EFAULT
+		}
+		if uintptr(unsafe.Pointer(futex.Futex))%4 != 0 {
+			mem.mu.Unlock()
+			return -1, syscall.EINVAL
+		}
+		if atomic.LoadUint32(futex.Futex) != futex.Value {
+			mem.mu.Unlock()
+			return -1, syscall.EAGAIN
+		}
+	}
+	if mem.futexes == nil {
+		mem.futexes = make(map[*uint32][]memoryFutex)
+	}
+	for i, futex := range futexes {
+		waiter.futexes[i] = futex.Futex
+		mem.futexes[futex.Futex] = append(mem.futexes[futex.Futex], memoryFutex{waiter: waiter, index: i, bitset: bitset})
+	}
+	mem.mu.Unlock()
+	var expired <-chan time.Time
+	if timeout >= 0 {
+		var timer = time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+	select {
+	case i := <-waiter.woken:
+		return i, 0
+	case <-expired:
+	}
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	if waiter.done {
+		return <-waiter.woken, 0
+	}
+	waiter.done = true
+	for _, addr := range waiter.futexes {
+		mem.futexes[addr] = slices.DeleteFunc(mem.futexes[addr], func(f memoryFutex) bool { return f.waiter == waiter })
+		if len(mem.futexes[addr]) == 0 {
+			delete(mem.futexes, addr)
+		}
+	}
+	return -1, syscall.ETIMEDOUT
+}
+
+// wakeFutex wakes up to count waiters on the futex at addr whose bitset
+// intersects with bitset, then moves up to requeue of the rest to the futex
+// at to, returns the number of waiters woken or moved.
+func (mem *memory) wakeFutex(addr *uint32, count int, bitset uint32, requeue int, to *uint32) int {
+	var n int
+	var kept, requeued []memoryFutex
+	for _, futex := range mem.futexes[addr] {
+		switch {
+		case futex.waiter.done:
+		case count > 0 && futex.bitset&bitset != 0:
+			futex.waiter.done = true
+			futex.waiter.woken <- futex.index
+			count--
+			n++
+		case requeue > 0 && to != nil:
+			futex.waiter.futexes[futex.index] = to
+			requeued = append(requeued, futex)
+			requeue--
+			n++
+		default:
+			kept = append(kept, futex)
+		}
+	}
+	if len(kept) == 0 {
+		delete(mem.futexes, addr)
+	} else {
+		mem.futexes[addr] = kept
+	}
+	// requeued after addr is written, as to may be addr.
+	if len(requeued) > 0 {
+		mem.futexes[to] = append(mem.futexes[to], requeued...)
+	}
+	return n
+}
+
+func (mem *memory) waitFutex(addr *uint32, value uint32, timeout *Time, flags FutexFlags) error {
+	duration, errno := mem.futexTimeout(timeout, false, flags)
+	if errno == 0 {
+		_, errno = mem.waitFutexes([]FutexToWait{{Futex: addr, Value: value}}, futexBitsetAny, duration)
+	}
+	return new(FutexError).parse(errno)
+}
+
+func (mem *memory) waitFutexBitset(addr *uint32, value uint32, deadline *Time, bitset uint32, flags FutexFlags) error {
+	if bitset == 0 {
+		return new(FutexError).Types().Invalid
+	}
+	duration, errno := mem.futexTimeout(deadline, true, flags)
+	if errno == 0 {
+		_, errno = mem.waitFutexes([]FutexToWait{{Futex: addr, Value: value}}, bitset, duration)
+	}
+	return new(FutexError).parse(errno)
+}
+
+func (mem *memory) wakeFutexBitset(addr *uint32, count int, bitset uint32, flags FutexFlags) (int, error) {
+	if addr == nil {
+		return 0, new(FutexError).Types().Fault
+	}
+	if bitset == 0 || count < 0 || uintptr(unsafe.Pointer(addr))%4 != 0 {
+		return 0, new(FutexError).Types().Invalid
+	}
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	return mem.wakeFutex(addr, count, bitset, 0, nil), nil
+}
+
+func (mem *memory) requeueFutex(addr *uint32, value uint32, wake, requeue int, to *uint32, flags FutexFlags) (int, error) {
+	if addr == nil || to == nil {
+		return 0, new(FutexError).Types().Fault
+	}
+	if wake < 0 || requeue < 0 || uintptr(unsafe.Pointer(addr))%4 != 0 || uintptr(unsafe.Pointer(to))%4 != 0 {
+		return 0, new(FutexError).Types().Invalid
+	}
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	if atomic.LoadUint32(addr) != value {
+		return 0, new(FutexError).Types().WouldBlock
+	}
+	if mem.futexes == nil {
+		mem.futexes = make(map[*uint32][]memoryFutex)
+	}
+	return mem.wakeFutex(addr, wake, futexBitsetAny, requeue, to), nil
+}
+
+func (mem *memory) waitFutexesWithDeadline(futexes []FutexToWait, deadline *Time, flags FutexFlags) (int, error) {
+	if len(futexes) == 0 || len(futexes) > futexWaitMultipleMax {
+		return -1, new(FutexError).Types().Invalid
+	}
+	duration, errno := mem.futexTimeout(deadline, true, flags)
+	if errno != 0 {
+		return -1, new(FutexError).parse(errno)
+	}
+	i, errno := mem.waitFutexes(futexes, futexBitsetAny, duration)
+	return i, new(FutexError).parse(errno)
+}
+
+func (mem *memory) clockTime(clock Clock) (Time, error) {
+	switch clock {
+	case ClockRealtime:
+		return mem.now(), nil
+	case ClockMonotonic, ClockBoottime:
+		return TimeFromDuration(time.Since(mem.started)), nil
+	}
+	return Time{}, new(ClockError).Types().Invalid
+}
+
+func (mem *memory) clockResolution(clock Clock) (Time, error) {
+	if _, err := mem.clockTime(clock); err != nil {
+		return Time{}, err
+	}
+	return Time{Nanos: 1}, nil
+}
+
+func (mem *memory) sleep(clock Clock, flags Sleep, t Time) (Time, error) {
+	if clock == ClockThreadTime {
+		return Time{}, new(SleepError).Types().Unsupported
+	}
+	now, err := mem.clockTime(clock)
+	if err != nil || flags&^SleepUntil != 0 || t.Seconds < 0 || t.Nanos < 0 || t.Nanos >= 1e9 {
+		return Time{}, new(SleepError).Types().Invalid
+	}
+	var duration = t.AsDuration()
+	if flags&SleepUntil != 0 {
+		duration -= now.AsDuration()
+		if clock == ClockRealtime {
+			duration = time.Until(t.AsTime())
+		}
+	}
+	time.Sleep(duration)
+	return Time{}, nil
+}
+
+func (m *memoryMap) ReadAt(p []byte, off int64) (n int, err error) {
+	m.mem.mu.Lock()
+	defer m.mem.mu.Unlock()
+	if m.prot&MemoryAllowReads == 0 {
+		return 0, new(MapError).parse(syscall.EACCES)
+	}
+	if off < 0 || off > int64(len(m.slice)) {
+		return 0, new(MapError).parse(syscall.EINVAL)
+	}
+	return copy(p, m.slice[off:]), nil
+}
+
+func (m *memoryMap) WriteAt(p []byte, off int64) (n int, err error) {
+	m.mem.mu.Lock()
+	defer m.mem.mu.Unlock()
+	if m.prot&MemoryAllowWrites == 0 {
+		return 0, new(MapError).parse(syscall.EACCES)
+	}
+	if off < 0 || off > int64(len(m.slice)) {
+		return 0, new(MapError).parse(syscall.EINVAL)
+	}
+	return copy(m.slice[off:], p), nil
+}
+
+func (m *memoryMap) Close() error {
+	m.mem.mu.Lock()
+	defer m.mem.mu.Unlock()
+	delete(m.mem.maps, m.UnsafePointer())
+	return nil
+}
+
+func (m *memoryMap) Len() int { return len(m.slice) }
+
+func (m *memoryMap) UnsafePointer() unsafe.Pointer { return unsafe.Pointer(unsafe.SliceData(m.slice)) }
+
+func (m *memoryMap) Remap(newLength int, flags Remap) (MappedMemory, error) {
+	mapped, err := m.mem.remap(m.UnsafePointer(), len(m.slice), newLength, flags&^RemapExactAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	return mapped, nil
+}
+
+func (m *memoryMap) Advise(advice MemoryAdvice) error {
+	return m.mem.adviseMemory(m.UnsafePointer(), len(m.slice), advice)
+}
+
+func (m *memoryMap) Lock(flags MemoryLock) error {
+	return m.mem.lockMemory(m.UnsafePointer(), len(m.slice), flags)
+}
+
+func (m *memoryMap) Unlock() error { return m.mem.unlockMemory(m.UnsafePointer(), len(m.slice)) }
+
+func (m *memoryMap) Residency(pages []byte) error {
+	return m.mem.memoryResidency(m.UnsafePointer(), len(m.slice), pages)
+}
+
+func (m *memoryMap) Sync(flags MemorySync) error {
+	return m.mem.syncMemory(m.UnsafePointer(), len(m.slice), flags)
+}
@@ -0,0 +1,121 @@
+package linux_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+	"time"
+
+	"verbose.style/linux"
+)
+
+func TestMemory(t *testing.T) {
+	var Linux = linux.Memory()
+
+	if _, err := Linux.Stat("/missing"); err != new(linux.StatError).Types().DoesNotExist {
+		t.Fatal(err)
+	}
+	f, err := Linux.Open("/file", linux.FileAccessReadWrite, linux.FileCreateIfNeeded|linux.FileAssertCreation, 0, linux.FileReadableByUser|linux.FileWritableByUser)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Linux.Open("/file", linux.FileAccessReadWrite, linux.FileCreateIfNeeded|linux.FileAssertCreation, 0, 0); err != new(linux.OpenError).Types().AlreadyExists {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Seek(3*4096, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte("world")); err != nil {
+		t.Fatal(err)
+	}
+	header, err := f.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Size != 3*4096+5 || header.BlockCount != 2*4096/512 {
+		t.Fatal(header.Size, header.BlockCount)
+	}
+	if hole, err := Linux.Seek(f.Descriptor, 0, linux.SeekHole); hole != 4096 || err != nil {
+		t.Fatal(hole, err)
+	}
+	if data, err := Linux.Seek(f.Descriptor, 4096, linux.SeekData); data != 3*4096 || err != nil {
+		t.Fatal(data, err)
+	}
+	if _, err := Linux.Seek(f.Descriptor, header.Size, linux.SeekData); err != new(linux.SeekError).Types().NotFound {
+		t.Fatal(err)
+	}
+
+	mapped, err := f.MapIntoMemory(linux.MapShared, linux.MemoryAllowReads|linux.MemoryAllowWrites, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mapped.WriteAt([]byte("HELLO"), 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	var buf [5]byte
+	if _, err := io.ReadFull(&f, buf[:]); err != nil || !bytes.Equal(buf[:], []byte("HELLO")) {
+		t.Fatal(string(buf[:]), err)
+	}
+	if err := Linux.ProtectMemory(mapped.UnsafePointer(), mapped.Len(), linux.MemoryAllowReads); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mapped.WriteAt([]byte{1}, 0); err == nil {
+		t.Fatal("expected error")
+	}
+	if err := mapped.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Linux.Read(f.Descriptor, buf[:]); err != new(linux.ReadError).Types().BadFile {
+		t.Fatal(err)
+	}
+	if _, err := Linux.Open("/file", linux.FileAccessReadOnly, linux.FileAssertDirectory, 0, 0); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := Linux.Open("/file/child", linux.FileAccessReadOnly, 0, 0, 0); err == nil {
+		t.Fatal("expected error")
+	}
+
+	var polls = []linux.FileToPoll{{File: 99, Notify: linux.PollHasReadAvailable}}
+	if n, err := Linux.Poll(polls, 0); n != 1 || err != nil || polls[0].Result != linux.PollHasInvalidRequest {
+		t.Fatal(n, err, polls[0].Result)
+	}
+}
+
+func TestMemoryPoll(t *testing.T) {
+	var Linux = linux.Memory()
+	var start = time.Now()
+	if n, err := Linux.Poll(nil, 20*time.Millisecond); n != 0 || err != nil {
+		t.Fatal(n, err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Fatalf("poll without files returned after %v, expected it to sleep for the timeout", elapsed)
+	}
+	event, err := Linux.CreateEvent(0, linux.EventNonBlocking)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer event.Close()
+	var polls = []linux.FileToPoll{{File: event.Descriptor, Notify: linux.PollHasReadAvailable}}
+	if n, err := Linux.Poll(polls, 10*time.Millisecond); n != 0 || err != nil || polls[0].Result != 0 {
+		t.Fatal(n, err, polls[0].Result)
+	}
+	time.AfterFunc(10*time.Millisecond, func() {
+		var one [8]byte
+		binary.NativeEndian.PutUint64(one[:], 1)
+		Linux.Write(event.Descriptor, one[:])
+	})
+	if n, err := Linux.Poll(polls, -1); n != 1 || err != nil || polls[0].Result != linux.PollHasReadAvailable {
+		t.Fatal(n, err, polls[0].Result)
+	}
+}
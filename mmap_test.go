@@ -0,0 +1,218 @@
+package linux_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"testing"
+	"unsafe"
+
+	"verbose.style/linux"
+)
+
+func TestMappedMemory(t *testing.T) {
+	var page = os.Getpagesize()
+	for name, api := range map[string]*linux.API{
+		"Native": linux.Native(),
+		"Memory": linux.Memory(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			m, err := api.MapIntoMemory(nil, page, linux.MemoryAllowReads|linux.MemoryAllowWrites, linux.MapPrivate, linux.MapAnonymous, -1, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := m.WriteAt([]byte("hello"), 0); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := m.Remap(4*page, 0); !errors.Is(err, new(linux.RemapError).Types().OutOfMemory) && err != nil {
+				t.Fatalf("expected out of memory or success, got %v", err)
+			}
+			grown, err := m.Remap(4*page, linux.RemapMayMove)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() {
+				if err := grown.Close(); err != nil {
+					t.Error(err)
+				}
+			}()
+			var buf [5]byte
+			if _, err := grown.ReadAt(buf[:], 0); err != nil || string(buf[:]) != "hello" || grown.Len() != 4*page {
+				t.Fatalf("remapped %q %d %v", buf, grown.Len(), err)
+			}
+			if _, err := grown.WriteAt([]byte("world"), int64(3*page)); err != nil {
+				t.Fatal(err)
+			}
+			if err := grown.Advise(linux.MemoryWillBeNeeded); err != nil {
+				t.Fatal(err)
+			}
+			if err := grown.Advise(linux.MemoryAdvice(-1)); !errors.Is(err, new(linux.AdviseMemoryError).Types().Invalid) {
+				t.Fatalf("expected invalid advice, got %v", err)
+			}
+			if err := grown.Lock(linux.MemoryLockOnFault); err != nil && !errors.Is(err, new(linux.LockMemoryError).Types().NotPermitted) && !errors.Is(err, new(linux.LockMemoryError).Types().OutOfMemory) {
+				t.Fatal(err)
+			} else if err == nil {
+				if err := grown.Unlock(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			var pages = make([]byte, 4)
+			if err := grown.Residency(pages); err != nil {
+				t.Fatal(err)
+			}
+			if pages[0]&1 == 0 || pages[3]&1 == 0 {
+				t.Fatalf("expected written pages to be resident, got %v", pages)
+			}
+			if err := grown.Residency(pages[:3]); !errors.Is(err, new(linux.MemoryResidencyError).Types().Invalid) {
+				t.Fatalf("expected invalid for a short buffer, got %v", err)
+			}
+			if err := api.AdviseMemory(grown.UnsafePointer(), page, linux.MemoryNotNeeded); err != nil {
+				t.Fatal(err)
+			}
+			if err := grown.Sync(linux.MemorySyncAsynchronously | linux.MemorySyncSynchronously); !errors.Is(err, new(linux.SyncMemoryError).Types().Invalid) {
+				t.Fatalf("expected invalid sync, got %v", err)
+			}
+			shrunk, err := grown.Remap(page, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			grown = shrunk
+			if shrunk.Len() != page {
+				t.Fatalf("shrunk to %d", shrunk.Len())
+			}
+		})
+	}
+}
+
+func TestSyncMemory(t *testing.T) {
+	var api = linux.Native()
+	var name = filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(name, make([]byte, os.Getpagesize()), 0644); err != nil {
+		t.Fatal(err)
+	}
+	file, err := api.Open(linux.Path(name), linux.FileAccessReadWrite, 0, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	m, err := file.MapIntoMemory(linux.MapShared, linux.MemoryAllowReads|linux.MemoryAllowWrites, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	if _, err := m.WriteAt([]byte("synced"), 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Sync(linux.MemorySyncSynchronously); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(name)
+	if err != nil || string(data[:6]) != "synced" {
+		t.Fatalf("read %q %v", data[:6], err)
+	}
+}
+
+// reserve a free region of the address space, which is unmapped again, so
+// that it can be used for fixed mappings.
+func reserve(t *testing.T, api *linux.API, length int) unsafe.Pointer {
+	t.Helper()
+	m, err := api.MapIntoMemory(nil, length, linux.MemoryNotAccessible, linux.MapPrivate, linux.MapAnonymous, -1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var addr = m.UnsafePointer()
+	if err := m.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return addr
+}
+
+func TestMapAddress(t *testing.T) {
+	var api = linux.Native()
+	var page = os.Getpagesize()
+	var rw = linux.MemoryAllowReads | linux.MemoryAllowWrites
+	t.Run("Hint", func(t *testing.T) {
+		var addr = reserve(t, api, page)
+		m, err := api.MapIntoMemory(addr, page, rw, linux.MapPrivate, linux.MapAnonymous, -1, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer m.Close()
+		if m.UnsafePointer() != addr {
+			t.Fatalf("hint %p was not used, mapped at %p", addr, m.UnsafePointer())
+		}
+	})
+	t.Run("Exact", func(t *testing.T) {
+		m, err := api.MapIntoMemory(nil, 4*page, rw, linux.MapPrivate, linux.MapAnonymous, -1, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer m.Close()
+		var addr = unsafe.Add(m.UnsafePointer(), page)
+		if _, err := m.WriteAt([]byte("old"), int64(page)); err != nil {
+			t.Fatal(err)
+		}
+		fixed, err := api.MapIntoMemory(addr, page, rw, linux.MapPrivate, linux.MapAnonymous|linux.MapExactAddress, -1, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fixed.UnsafePointer() != addr || fixed.Len() != page {
+			t.Fatalf("mapped at %p, %d, expected %p", fixed.UnsafePointer(), fixed.Len(), addr)
+		}
+		var buf [3]byte
+		if _, err := m.ReadAt(buf[:], int64(page)); err != nil || buf != [3]byte{} {
+			t.Fatalf("fixed mapping did not replace the old one, read %q %v", buf, err)
+		}
+	})
+	t.Run("ExactOnce", func(t *testing.T) {
+		var addr = reserve(t, api, page)
+		m, err := api.MapIntoMemory(addr, page, rw, linux.MapPrivate, linux.MapAnonymous|linux.MapExactAddressOnce, -1, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer m.Close()
+		if m.UnsafePointer() != addr {
+			t.Fatalf("mapped at %p, expected %p", m.UnsafePointer(), addr)
+		}
+		_, err = api.MapIntoMemory(addr, page, rw, linux.MapPrivate, linux.MapAnonymous|linux.MapExactAddressOnce, -1, 0)
+		if !errors.Is(err, new(linux.MapError).Types().AlreadyExists) {
+			t.Fatalf("expected already exists, got %v", err)
+		}
+	})
+	t.Run("GrowsDown", func(t *testing.T) {
+		// leave room below the stack for the kernel's stack guard gap.
+		var region = 1024 * page
+		var base = reserve(t, api, region)
+		var addr = unsafe.Add(base, region-4*page)
+		stack, err := api.MapIntoMemory(addr, 4*page, rw, linux.MapPrivate, linux.MapAnonymous|linux.MapExactAddressOnce|linux.MapGrowsDown|linux.MapStack, -1, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer stack.Close()
+		if stack.UnsafePointer() != addr {
+			t.Fatalf("mapped at %p, expected %p", stack.UnsafePointer(), addr)
+		}
+		var below = unsafe.Add(addr, -page)
+		var pages [1]byte
+		if err := api.MemoryResidency(below, page, pages[:]); !errors.Is(err, new(linux.MemoryResidencyError).Types().OutOfMemory) {
+			t.Fatalf("expected the page below the stack to be unmapped, got %v", err)
+		}
+		func() {
+			defer debug.SetPanicOnFault(debug.SetPanicOnFault(true))
+			defer func() {
+				if r := recover(); r != nil {
+					t.Fatal("stack did not grow down:", r)
+				}
+			}()
+			*(*byte)(unsafe.Add(addr, -1)) = 1
+		}()
+		if err := api.MemoryResidency(below, page, pages[:]); err != nil {
+			t.Fatalf("expected the stack to have grown down, got %v", err)
+		}
+		// the grown page is not part of stack, so replace and unmap it separately.
+		if grown, err := api.MapIntoMemory(below, page, linux.MemoryNotAccessible, linux.MapPrivate, linux.MapAnonymous|linux.MapExactAddress, -1, 0); err == nil {
+			grown.Close()
+		}
+	})
+}
@@ -0,0 +1,395 @@
+//go:build linux && (386 || amd64 || arm64 || riscv64)
+
+package linux
+
+import (
+	"context"
+	"errors"
+	"math"
+	"runtime"
+	"slices"
+	"structs"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// Mutex is a mutual exclusion lock in mapped memory, that can be shared with
+// other processes that map the same memory, see [NewMutex]. The lock is held
+// by a process rather than a goroutine, so it can be unlocked by any goroutine
+// of the process that locked it. Mutexes are robust: when a process exits
+// while holding one, the kernel releases it through the robust futex list of
+// the process, and the next [Mutex.Lock] reports [MutexError.OwnerDied].
+type Mutex struct {
+	linux *API
+	state *mutexState
+}
+
+// mutexState is the shared memory of a [Mutex]. Next links the mutex into the
+// robust futex list of its owner, which the kernel finds the futex word from.
+type mutexState struct {
+	_ structs.HostLayout
+
+	Next  uintptr // entry of the robust futex list, while locked.
+	Owner uint32  // thread that registered the robust futex list of the owner, and futex bits.
+}
+
+// Condition variable in mapped memory, that processes waiting for a change to
+// memory protected by a [Mutex] can be woken with, see [NewCondition].
+type Condition struct {
+	linux    *API
+	mutex    *Mutex
+	sequence *uint32 // incremented by each signal.
+}
+
+// Semaphore in mapped memory, that can be shared with other processes that
+// map the same memory, see [NewSemaphore]. Semaphores have no owner, so they
+// are not affected by a process exiting, other than by the permits it holds.
+type Semaphore struct {
+	linux *API
+	state *semaphoreState
+}
+
+type semaphoreState struct {
+	_ structs.HostLayout
+
+	Count   uint32 // permits available.
+	Waiters uint32 // processes waiting for a permit.
+}
+
+const (
+	MutexSize     = int(unsafe.Sizeof(mutexState{}))     // bytes of mapped memory used by a [Mutex].
+	ConditionSize = int(unsafe.Sizeof(uint32(0)))        // bytes of mapped memory used by a [Condition].
+	SemaphoreSize = int(unsafe.Sizeof(semaphoreState{})) // bytes of mapped memory used by a [Semaphore].
+)
+
+const (
+	futexWaiters   = 0x80000000 // FUTEX_WAITERS
+	futexOwnerDied = 0x40000000 // FUTEX_OWNER_DIED
+	futexOwner     = 0x3fffffff // FUTEX_TID_MASK
+
+	// mutexNotRecoverable is held by a mutex that was unlocked while
+	// inconsistent, which is never a thread ID.
+	mutexNotRecoverable = futexOwnerDied | futexOwner
+
+	// futexInterval at which waits check whether their context is done, in
+	// case it was done just before they started to wait.
+	futexInterval = 100 * time.Millisecond
+)
+
+// robust futex list of the process, which is registered by a thread that is
+// dedicated to it, so that the list is not replaced by the C library or
+// released while the process is running. The kernel walks the list when the
+// thread exits, which is when the process does.
+var robust struct {
+	once  sync.Once
+	mu    sync.Mutex
+	head  robustListHead
+	held  []*mutexState // entries of the list, in order.
+	owner uint32        // ID of the thread that registered the list.
+	err   error         // from registering the list, returned by [NewMutex].
+}
+
+// robustOwner returns the owner that mutexes are locked as, registering the
+// robust futex list of the process if it has not been yet.
+func robustOwner() uint32 {
+	robust.once.Do(func() {
+		var registered = make(chan uint32)
+		go func() {
+			runtime.LockOSThread()
+			robust.head.Next = uintptr(unsafe.Pointer(&robust.head))
+			robust.head.FutexOffset = int(unsafe.Offsetof(mutexState{}.Owner))
+			if errno := setRobustList(&robust.head); errno != 0 {
+				robust.err = new(MutexError).parse(errno)
+			}
+			registered <- uint32(syscall.Gettid())
+			select {}
+		}()
+		robust.owner = <-registered
+	})
+	return robust.owner
+}
+
+// NewMutex returns the [Mutex] at offset in m, which must be [MutexSize] bytes
+// that are zero for an unlocked mutex. Each process that shares the mutex
+// calls NewMutex on its own mapping of the memory, which must stay mapped for
+// as long as the mutex is locked. NewMutex fails when the robust futex list of
+// the process cannot be registered, as the mutex would not be released when
+// the process exits.
+func NewMutex(api *API, m MappedMemory, offset int) (*Mutex, error) {
+	state, err := View[mutexState](m, offset)
+	if err != nil {
+		return nil, err
+	}
+	if robustOwner(); robust.err != nil {
+		return nil, robust.err
+	}
+	return &Mutex{linux: api, state: state}, nil
+}
+
+// Lock the mutex, waiting until it is unlocked or ctx is done. When the
+// previous owner exited without unlocking it, the mutex is locked but Lock
+// returns [MutexError.OwnerDied], so that the caller can repair the state it
+// protects and call [Mutex.Consistent], before calling [Mutex.Unlock].
+func (m *Mutex) Lock(ctx context.Context) error {
+	return m.lock(ctx, false)
+}
+
+// TryLock locks the mutex if it is unlocked, see [Mutex.Lock].
+func (m *Mutex) TryLock() (bool, error) {
+	var state = atomic.LoadUint32(&m.state.Owner)
+	if state == mutexNotRecoverable {
+		return false, new(MutexError).Types().NotRecoverable
+	}
+	if state&futexOwner != 0 || !m.acquire(state, robustOwner()|state&(futexOwnerDied|futexWaiters)) {
+		return false, nil
+	}
+	if state&futexOwnerDied != 0 {
+		return true, new(MutexError).Types().OwnerDied
+	}
+	return true, nil
+}
+
+// lock the mutex, as contended if the caller has already waited for it, so
+// that it is sure to wake up the next waiter when it unlocks the mutex.
+func (m *Mutex) lock(ctx context.Context, contended bool) error {
+	var owner = robustOwner()
+	for {
+		var state = atomic.LoadUint32(&m.state.Owner)
+		switch {
+		case state == mutexNotRecoverable:
+			return new(MutexError).Types().NotRecoverable
+		case state&futexOwner == 0:
+			var locked = owner | state&(futexOwnerDied|futexWaiters)
+			if contended {
+				locked |= futexWaiters
+			}
+			if !m.acquire(state, locked) {
+				continue
+			}
+			if state&futexOwnerDied != 0 {
+				return new(MutexError).Types().OwnerDied
+			}
+			return nil
+		case state&futexWaiters == 0:
+			if !atomic.CompareAndSwapUint32(&m.state.Owner, state, state|futexWaiters) {
+				continue
+			}
+			state |= futexWaiters
+		}
+		contended = true
+		if err := waitFutex(ctx, m.linux, "Lock", &m.state.Owner, state, 0); err != nil {
+			return err
+		}
+	}
+}
+
+// acquire the mutex, by swapping its state for locked, and add it to the
+// robust futex list, while marking it as pending so that the kernel releases
+// it if the process exits in between.
+func (m *Mutex) acquire(state, locked uint32) bool {
+	robust.mu.Lock()
+	defer robust.mu.Unlock()
+	robust.head.Pending = uintptr(unsafe.Pointer(m.state))
+	defer func() { robust.head.Pending = 0 }()
+	if !atomic.CompareAndSwapUint32(&m.state.Owner, state, locked) {
+		return false
+	}
+	m.state.Next = robust.head.Next
+	robust.head.Next = uintptr(unsafe.Pointer(m.state))
+	robust.held = slices.Insert(robust.held, 0, m.state)
+	return true
+}
+
+// Consistent marks the state protected by the mutex as repaired, after
+// [Mutex.Lock] reported [MutexError.OwnerDied]. A mutex that is unlocked
+// without being marked consistent can no longer be locked.
+func (m *Mutex) Consistent() error {
+	var state = atomic.LoadUint32(&m.state.Owner)
+	if state&futexOwner != robustOwner() {
+		return new(MutexError).Types().NotPermitted
+	}
+	if state&futexOwnerDied == 0 {
+		return new(MutexError).Types().Invalid
+	}
+	for !atomic.CompareAndSwapUint32(&m.state.Owner, state, state&^futexOwnerDied) {
+		state = atomic.LoadUint32(&m.state.Owner)
+	}
+	return nil
+}
+
+// Unlock the mutex, which must be locked by this process, and wake up one of
+// the processes waiting for it.
+func (m *Mutex) Unlock() error {
+	var state = atomic.LoadUint32(&m.state.Owner)
+	if state == mutexNotRecoverable || state&futexOwner != robustOwner() {
+		return new(MutexError).Types().NotPermitted
+	}
+	var unlocked uint32
+	if state&futexOwnerDied != 0 {
+		unlocked = mutexNotRecoverable
+	}
+	robust.mu.Lock()
+	robust.head.Pending = uintptr(unsafe.Pointer(m.state))
+	if i := slices.Index(robust.held, m.state); i >= 0 {
+		if i == 0 {
+			robust.head.Next = m.state.Next
+		} else {
+			robust.held[i-1].Next = m.state.Next
+		}
+		robust.held = slices.Delete(robust.held, i, i+1)
+	}
+	state = atomic.SwapUint32(&m.state.Owner, unlocked)
+	robust.head.Pending = 0
+	robust.mu.Unlock()
+	switch {
+	case unlocked == mutexNotRecoverable:
+		_, err := m.linux.WakeFutex(&m.state.Owner, math.MaxInt32, 0)
+		return err
+	case state&futexWaiters != 0:
+		_, err := m.linux.WakeFutex(&m.state.Owner, 1, 0)
+		return err
+	}
+	return nil
+}
+
+// NewCondition returns the [Condition] at offset in m, which must be
+// [ConditionSize] bytes, whose waiters hold mutex, see [NewMutex].
+func NewCondition(api *API, m MappedMemory, offset int, mutex *Mutex) (*Condition, error) {
+	sequence, err := View[uint32](m, offset)
+	if err != nil {
+		return nil, err
+	}
+	return &Condition{linux: api, mutex: mutex, sequence: sequence}, nil
+}
+
+// Wait unlocks the mutex of the condition and waits until the condition is
+// signalled or ctx is done, then locks the mutex again before returning,
+// even if ctx is done. Waits can wake up spuriously, so the caller must check
+// the state it is waiting for again.
+func (c *Condition) Wait(ctx context.Context) error {
+	var sequence = atomic.LoadUint32(c.sequence)
+	if err := c.mutex.Unlock(); err != nil {
+		return err
+	}
+	var err = waitFutex(ctx, c.linux, "Wait", c.sequence, sequence, 0)
+	if err := c.mutex.lock(context.WithoutCancel(ctx), true); err != nil {
+		return err
+	}
+	return err
+}
+
+// Signal wakes up one of the processes waiting on the condition.
+func (c *Condition) Signal() error {
+	atomic.AddUint32(c.sequence, 1)
+	_, err := c.linux.WakeFutex(c.sequence, 1, 0)
+	return err
+}
+
+// Broadcast wakes up all of the processes waiting on the condition. While
+// the mutex is locked, all but one of them are moved to wait on the mutex
+// instead, so that they do not all wake up only to wait for it again.
+func (c *Condition) Broadcast() error {
+	var sequence = atomic.AddUint32(c.sequence, 1)
+	var mutex = &c.mutex.state.Owner
+	for {
+		var state = atomic.LoadUint32(mutex)
+		if state&futexOwner == 0 || state == mutexNotRecoverable {
+			_, err := c.linux.WakeFutex(c.sequence, math.MaxInt32, 0)
+			return err
+		}
+		if state&futexWaiters != 0 || atomic.CompareAndSwapUint32(mutex, state, state|futexWaiters) {
+			break
+		}
+	}
+	_, err := c.linux.RequeueFutex(c.sequence, sequence, 1, math.MaxInt32, mutex, 0)
+	if errors.Is(err, new(FutexError).Types().WouldBlock) {
+		_, err = c.linux.WakeFutex(c.sequence, math.MaxInt32, 0)
+	}
+	return err
+}
+
+// NewSemaphore returns the [Semaphore] at offset in m, which must be
+// [SemaphoreSize] bytes, see [NewMutex]. The semaphore starts out with no
+// permits, see [Semaphore.Release].
+func NewSemaphore(api *API, m MappedMemory, offset int) (*Semaphore, error) {
+	state, err := View[semaphoreState](m, offset)
+	if err != nil {
+		return nil, err
+	}
+	return &Semaphore{linux: api, state: state}, nil
+}
+
+// Acquire a permit from the semaphore, waiting until one is available or ctx
+// is done.
+func (s *Semaphore) Acquire(ctx context.Context) error {
+	for {
+		if s.TryAcquire() {
+			return nil
+		}
+		atomic.AddUint32(&s.state.Waiters, 1)
+		var err = waitFutex(ctx, s.linux, "Acquire", &s.state.Count, 0, 0)
+		atomic.AddUint32(&s.state.Waiters, ^uint32(0))
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// TryAcquire acquires a permit from the semaphore if one is available.
+func (s *Semaphore) TryAcquire() bool {
+	for {
+		var count = atomic.LoadUint32(&s.state.Count)
+		if count == 0 {
+			return false
+		}
+		if atomic.CompareAndSwapUint32(&s.state.Count, count, count-1) {
+			return true
+		}
+	}
+}
+
+// Release n permits to the semaphore, waking up as many waiters.
+func (s *Semaphore) Release(n int) error {
+	if n <= 0 || n > math.MaxInt32 {
+		return new(FutexError).Types().Invalid
+	}
+	atomic.AddUint32(&s.state.Count, uint32(n))
+	if atomic.LoadUint32(&s.state.Waiters) == 0 {
+		return nil
+	}
+	_, err := s.linux.WakeFutex(&s.state.Count, n, 0)
+	return err
+}
+
+// waitFutex waits on the futex at addr while it holds value, until it is
+// woken, ctx is done or the interval, if positive, expires. Spurious wake-ups
+// and timeouts are not reported, so the caller must check the futex again.
+func waitFutex(ctx context.Context, api *API, operation string, addr *uint32, value uint32, interval time.Duration) error {
+	if err := context.Cause(ctx); err != nil {
+		return &ContextError{Operation: operation, Err: err}
+	}
+	if ctx.Done() != nil {
+		stop := context.AfterFunc(ctx, func() { api.WakeFutex(addr, math.MaxInt32, 0) })
+		defer stop()
+		if interval <= 0 || interval > futexInterval {
+			interval = futexInterval
+		}
+	}
+	var timeout *Time
+	if interval > 0 {
+		var relative = TimeFromDuration(interval)
+		timeout = &relative
+	}
+	var err = api.WaitFutex(addr, value, timeout, 0)
+	if err := context.Cause(ctx); err != nil {
+		return &ContextError{Operation: operation, Err: err}
+	}
+	if err != nil && !errors.Is(err, new(FutexError).Types().WouldBlock) &&
+		!errors.Is(err, new(FutexError).Types().TimedOut) && !errors.Is(err, new(FutexError).Types().Interrupted) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,306 @@
+package linux_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"verbose.style/linux"
+)
+
+// sharedMemory maps a page of a memory file, as shared with other processes.
+func sharedMemory(t *testing.T, api *linux.API, fd linux.FileDescriptor) linux.MappedMemory {
+	t.Helper()
+	mapped, err := api.MapIntoMemory(nil, syscall.Getpagesize(), linux.MemoryAllowReads|linux.MemoryAllowWrites, linux.MapShared, 0, fd, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { mapped.Close() })
+	return mapped
+}
+
+func TestMutex(t *testing.T) {
+	for name, api := range map[string]*linux.API{"Native": linux.Native(), "Memory": linux.Memory()} {
+		t.Run(name, func(t *testing.T) {
+			var ctx = context.Background()
+			mapped, err := api.MapIntoMemory(nil, syscall.Getpagesize(), linux.MemoryAllowReads|linux.MemoryAllowWrites, linux.MapPrivate, linux.MapAnonymous, -1, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mapped.Close()
+			mutex, err := linux.NewMutex(api, mapped, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			counter, err := linux.View[uint64](mapped, linux.MutexSize)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := mutex.Unlock(); !errors.Is(err, new(linux.MutexError).Types().NotPermitted) {
+				t.Fatalf("expected not permitted, got %v", err)
+			}
+			var wg sync.WaitGroup
+			for range 8 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for range 1000 {
+						if err := mutex.Lock(ctx); err != nil {
+							t.Error(err)
+							return
+						}
+						*counter++
+						if err := mutex.Unlock(); err != nil {
+							t.Error(err)
+							return
+						}
+					}
+				}()
+			}
+			wg.Wait()
+			if *counter != 8000 {
+				t.Fatalf("expected 8000 increments, got %d", *counter)
+			}
+
+			if ok, err := mutex.TryLock(); !ok || err != nil {
+				t.Fatalf("expected to lock the mutex, got %v, %v", ok, err)
+			}
+			if ok, err := mutex.TryLock(); ok || err != nil {
+				t.Fatalf("expected the mutex to be locked, got %v, %v", ok, err)
+			}
+			if err := mutex.Consistent(); !errors.Is(err, new(linux.MutexError).Types().Invalid) {
+				t.Fatalf("expected invalid, got %v", err)
+			}
+			timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+			defer cancel()
+			if err := mutex.Lock(timeout); !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("expected deadline exceeded, got %v", err)
+			}
+			if err := mutex.Unlock(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestCondition(t *testing.T) {
+	for name, api := range map[string]*linux.API{"Native": linux.Native(), "Memory": linux.Memory()} {
+		t.Run(name, func(t *testing.T) {
+			var ctx = context.Background()
+			mapped, err := api.MapIntoMemory(nil, syscall.Getpagesize(), linux.MemoryAllowReads|linux.MemoryAllowWrites, linux.MapPrivate, linux.MapAnonymous, -1, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mapped.Close()
+			mutex, err := linux.NewMutex(api, mapped, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cond, err := linux.NewCondition(api, mapped, linux.MutexSize, mutex)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ready, err := linux.View[uint32](mapped, linux.MutexSize+linux.ConditionSize)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var wg sync.WaitGroup
+			for range 4 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if err := mutex.Lock(ctx); err != nil {
+						t.Error(err)
+						return
+					}
+					for *ready == 0 {
+						if err := cond.Wait(ctx); err != nil {
+							t.Error(err)
+							break
+						}
+					}
+					mutex.Unlock()
+				}()
+			}
+			time.Sleep(10 * time.Millisecond)
+			if err := mutex.Lock(ctx); err != nil {
+				t.Fatal(err)
+			}
+			*ready = 1
+			if err := cond.Broadcast(); err != nil {
+				t.Fatal(err)
+			}
+			if err := mutex.Unlock(); err != nil {
+				t.Fatal(err)
+			}
+			wg.Wait()
+
+			mutex.Lock(ctx)
+			timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+			defer cancel()
+			if err := cond.Wait(timeout); !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("expected deadline exceeded, got %v", err)
+			}
+			if err := mutex.Unlock(); err != nil {
+				t.Fatalf("expected the mutex to be locked again, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSemaphore(t *testing.T) {
+	for name, api := range map[string]*linux.API{"Native": linux.Native(), "Memory": linux.Memory()} {
+		t.Run(name, func(t *testing.T) {
+			var ctx = context.Background()
+			mapped, err := api.MapIntoMemory(nil, syscall.Getpagesize(), linux.MemoryAllowReads|linux.MemoryAllowWrites, linux.MapPrivate, linux.MapAnonymous, -1, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mapped.Close()
+			sem, err := linux.NewSemaphore(api, mapped, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sem.TryAcquire() {
+				t.Fatal("acquired a permit from an empty semaphore")
+			}
+			var acquired = make(chan error, 3)
+			for range 3 {
+				go func() { acquired <- sem.Acquire(ctx) }()
+			}
+			time.Sleep(10 * time.Millisecond)
+			if err := sem.Release(3); err != nil {
+				t.Fatal(err)
+			}
+			for range 3 {
+				if err := <-acquired; err != nil {
+					t.Fatal(err)
+				}
+			}
+			timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+			defer cancel()
+			if err := sem.Acquire(timeout); !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("expected deadline exceeded, got %v", err)
+			}
+		})
+	}
+}
+
+// Offsets of the shared memory used by TestMutexAcrossProcesses.
+const (
+	sharedConsistent   = 0
+	sharedUnrepaired   = sharedConsistent + linux.MutexSize
+	sharedReady        = sharedUnrepaired + linux.MutexSize
+	sharedCounter      = 64
+	sharedIncrements   = 10000
+	sharedProcessCount = 2
+)
+
+func TestMutexAcrossProcesses(t *testing.T) {
+	var api = linux.Native()
+	var ctx = context.Background()
+	if os.Getenv("LINUX_TEST_MUTEX") != "" {
+		var mapped = sharedMemory(t, api, 3)
+		consistent, _ := linux.NewMutex(api, mapped, sharedConsistent)
+		unrepaired, _ := linux.NewMutex(api, mapped, sharedUnrepaired)
+		ready, _ := linux.NewSemaphore(api, mapped, sharedReady)
+		counter, _ := linux.View[uint64](mapped, sharedCounter)
+		for range sharedIncrements {
+			consistent.Lock(ctx)
+			*counter++
+			consistent.Unlock()
+		}
+		if consistent.Lock(ctx) != nil || unrepaired.Lock(ctx) != nil || ready.Release(1) != nil {
+			os.Exit(1)
+		}
+		time.Sleep(10 * time.Millisecond)
+		os.Exit(0) // without unlocking, as if the process crashed.
+	}
+	file, err := api.CreateMemoryFile("mutex", linux.MemoryFileCloseOnExecute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if err := api.Truncate(file.Descriptor, int64(syscall.Getpagesize())); err != nil {
+		t.Fatal(err)
+	}
+	var mapped = sharedMemory(t, api, file.Descriptor)
+	consistent, err := linux.NewMutex(api, mapped, sharedConsistent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unrepaired, err := linux.NewMutex(api, mapped, sharedUnrepaired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ready, err := linux.NewSemaphore(api, mapped, sharedReady)
+	if err != nil {
+		t.Fatal(err)
+	}
+	counter, err := linux.View[uint64](mapped, sharedCounter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fd, err := syscall.Dup(int(file.Descriptor))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cmd = exec.Command(os.Args[0], "-test.run=^TestMutexAcrossProcesses$")
+	cmd.Env = append(os.Environ(), "LINUX_TEST_MUTEX=1")
+	cmd.ExtraFiles = []*os.File{os.NewFile(uintptr(fd), "mutex")}
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	cmd.ExtraFiles[0].Close()
+	for range sharedIncrements {
+		if err := consistent.Lock(ctx); err != nil {
+			t.Fatal(err)
+		}
+		*counter++
+		if err := consistent.Unlock(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := ready.Acquire(timeout); err != nil {
+		t.Fatal(err)
+	}
+
+	// the child holds both mutexes until it exits.
+	if err := consistent.Lock(timeout); !errors.Is(err, new(linux.MutexError).Types().OwnerDied) {
+		t.Fatalf("expected owner died, got %v", err)
+	}
+	if err := cmd.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if *counter != sharedProcessCount*sharedIncrements {
+		t.Fatalf("expected %d increments, got %d", sharedProcessCount*sharedIncrements, *counter)
+	}
+	if err := consistent.Consistent(); err != nil {
+		t.Fatal(err)
+	}
+	if err := consistent.Unlock(); err != nil {
+		t.Fatal(err)
+	}
+	if err := consistent.Lock(ctx); err != nil {
+		t.Fatalf("expected the repaired mutex to lock, got %v", err)
+	}
+	consistent.Unlock()
+
+	if err := unrepaired.Lock(ctx); !errors.Is(err, new(linux.MutexError).Types().OwnerDied) {
+		t.Fatalf("expected owner died, got %v", err)
+	}
+	if err := unrepaired.Unlock(); err != nil {
+		t.Fatal(err)
+	}
+	if err := unrepaired.Lock(ctx); !errors.Is(err, new(linux.MutexError).Types().NotRecoverable) {
+		t.Fatalf("expected not recoverable, got %v", err)
+	}
+}
@@ -0,0 +1,371 @@
+//go:build linux && (386 || amd64 || arm64 || riscv64)
+
+package linux
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"math/bits"
+	"reflect"
+	"structs"
+	"sync/atomic"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// SharedQueue is a single-producer, single-consumer queue of T, in a memory
+// file that can be shared with another process by passing [SharedQueue.File]
+// to it, see [OpenSharedQueue]. T must have a host layout, see [View], and
+// both processes must agree on its layout. Blocked senders and receivers wait
+// on a futex and detect when the process at the other end has exited, as for
+// a pipe, receiving from a queue with no sender returns [io.EOF] once it is
+// empty and sending to a queue with no receiver returns
+// [WriteError.BrokenPipe]. Each end of the queue is used by one goroutine.
+type SharedQueue[T any] struct {
+	linux  *API
+	fd     FileDescriptor
+	mapped MappedMemory
+	header *sharedQueueHeader
+	slots  []T
+	mask   uint32
+
+	sender, receiver bool           // roles this end has taken on.
+	peer             FileDescriptor // pidfd of the other end, or -1.
+	peerID           int32          // process of the pidfd.
+	peerExited       bool           // process exited before its pidfd was opened.
+	closed           atomic.Bool
+}
+
+// sharedQueueHeader is the first page of the memory file of a [SharedQueue],
+// the head and tail are on their own cache lines, as they are written by
+// different processes, and double as futexes.
+type sharedQueueHeader struct {
+	_ structs.HostLayout
+
+	Magic          uint32
+	Version        uint32
+	Layout         uint64 // fingerprint of the layout of T.
+	Size           uint32 // of T.
+	Capacity       uint32 // a power of two.
+	Sender         int32  // process of the sender, or zero.
+	Receiver       int32  // process of the receiver, or zero.
+	SenderClosed   uint32
+	ReceiverClosed uint32
+	_              [24]byte
+	Head           uint32 // number of values sent.
+	HeadWaiters    uint32 // receivers waiting for the head to change.
+	_              [56]byte
+	Tail           uint32 // number of values received.
+	TailWaiters    uint32 // senders waiting for the tail to change.
+}
+
+// SharedQueueEnd of a [SharedQueue] that a process takes on when it creates
+// or opens the queue, so that the other end can tell once it has exited, even
+// before it has sent or received any values.
+type SharedQueueEnd uint8
+
+const (
+	SharedQueueSender   SharedQueueEnd = 1 << iota // sends values with [SharedQueue.Send].
+	SharedQueueReceiver                            // receives values with [SharedQueue.Receive].
+)
+
+const (
+	sharedQueueMagic   = 0x75657551 // "Queu"
+	sharedQueueVersion = 1
+
+	// sharedQueueInterval at which blocked ends check the other end is alive.
+	sharedQueueInterval = 50 * time.Millisecond
+)
+
+// NewSharedQueue creates a [SharedQueue] that holds up to capacity values,
+// rounded up to a power of two, for the given end, or both.
+func NewSharedQueue[T any](api *API, capacity int, end SharedQueueEnd) (*SharedQueue[T], error) {
+	if capacity <= 0 || capacity > 1<<30 || !end.valid() {
+		return nil, new(MapError).Types().Invalid
+	}
+	capacity = 1 << bits.Len(uint(capacity-1))
+	var rtype = reflect.TypeFor[T]()
+	if !hostLayout(rtype) {
+		return nil, &ViewError{Type: rtype, Count: capacity, Reason: "type does not have a host layout"}
+	}
+	file, err := api.CreateMemoryFile("queue", MemoryFileCloseOnExecute)
+	if err != nil {
+		return nil, err
+	}
+	if err := api.Truncate(file.Descriptor, int64(sharedQueueSize(capacity, int(rtype.Size())))); err != nil {
+		api.Close(file.Descriptor)
+		return nil, err
+	}
+	q, err := mapSharedQueue[T](api, file.Descriptor, capacity)
+	if err != nil {
+		api.Close(file.Descriptor)
+		return nil, err
+	}
+	q.header.Layout = layoutFingerprint(rtype)
+	q.header.Size = uint32(rtype.Size())
+	q.header.Capacity = uint32(capacity)
+	q.header.Version = sharedQueueVersion
+	q.take(end)
+	atomic.StoreUint32(&q.header.Magic, sharedQueueMagic)
+	return q, nil
+}
+
+// OpenSharedQueue opens the [SharedQueue] in the memory file fd, as created by
+// [NewSharedQueue], usually in another process. Queues created with a
+// different version, or for a T with a different layout, are rejected with a
+// [ViewError]. The queue takes ownership of fd, which is closed if the queue
+// cannot be opened.
+func OpenSharedQueue[T any](api *API, fd FileDescriptor, end SharedQueueEnd) (*SharedQueue[T], error) {
+	var rtype = reflect.TypeFor[T]()
+	var page = syscall.Getpagesize()
+	if !end.valid() {
+		api.Close(fd)
+		return nil, new(MapError).Types().Invalid
+	}
+	stat, err := api.StatFile(fd)
+	if err != nil {
+		api.Close(fd)
+		return nil, err
+	}
+	if stat.Size < int64(page) {
+		api.Close(fd)
+		return nil, new(MapError).Types().Invalid
+	}
+	header, err := api.MapIntoMemory(nil, page, MemoryAllowReads, MapShared, 0, fd, 0)
+	if err != nil {
+		api.Close(fd)
+		return nil, err
+	}
+	view, _ := View[sharedQueueHeader](header, 0)
+	var magic, version, layout, capacity = atomic.LoadUint32(&view.Magic), view.Version, view.Layout, int(view.Capacity)
+	header.Close()
+	if magic != sharedQueueMagic || version != sharedQueueVersion || layout != layoutFingerprint(rtype) ||
+		capacity <= 0 || stat.Size != int64(sharedQueueSize(capacity, int(rtype.Size()))) {
+		api.Close(fd)
+		return nil, &ViewError{Type: rtype, Count: capacity, Reason: "queue was created with a different version or layout"}
+	}
+	q, err := mapSharedQueue[T](api, fd, capacity)
+	if err != nil {
+		api.Close(fd)
+		return nil, err
+	}
+	q.take(end)
+	return q, nil
+}
+
+func (end SharedQueueEnd) valid() bool {
+	return end != 0 && end&^(SharedQueueSender|SharedQueueReceiver) == 0
+}
+
+// take on the end of the queue, by recording the process at it.
+func (q *SharedQueue[T]) take(end SharedQueueEnd) {
+	var pid = int32(syscall.Getpid())
+	if end&SharedQueueSender != 0 {
+		q.sender = true
+		atomic.StoreInt32(&q.header.Sender, pid)
+	}
+	if end&SharedQueueReceiver != 0 {
+		q.receiver = true
+		atomic.StoreInt32(&q.header.Receiver, pid)
+	}
+	switch end {
+	case SharedQueueSender:
+		q.watch(&q.header.Receiver)
+	case SharedQueueReceiver:
+		q.watch(&q.header.Sender)
+	}
+}
+
+// sharedQueueSize returns the size of the memory file of a queue.
+func sharedQueueSize(capacity, size int) int {
+	var page = syscall.Getpagesize()
+	return page + (capacity*size+page-1)&^(page-1)
+}
+
+func mapSharedQueue[T any](api *API, fd FileDescriptor, capacity int) (*SharedQueue[T], error) {
+	var page = syscall.Getpagesize()
+	var size = sharedQueueSize(capacity, int(unsafe.Sizeof(*new(T))))
+	mapped, err := api.MapIntoMemory(nil, size, MemoryAllowReads|MemoryAllowWrites, MapShared, 0, fd, 0)
+	if err != nil {
+		return nil, err
+	}
+	header, err := View[sharedQueueHeader](mapped, 0)
+	if err != nil {
+		mapped.Close()
+		return nil, err
+	}
+	slots, err := SliceOf[T](mapped, page, capacity)
+	if err != nil {
+		mapped.Close()
+		return nil, err
+	}
+	return &SharedQueue[T]{linux: api, fd: fd, mapped: mapped, header: header, slots: slots, mask: uint32(capacity - 1), peer: -1}, nil
+}
+
+// layoutFingerprint hashes the kinds, sizes and offsets that make up the
+// layout of a type, so that processes can check that they agree on it.
+func layoutFingerprint(rtype reflect.Type) uint64 {
+	var hash = fnv.New64a()
+	var write func(reflect.Type)
+	write = func(rtype reflect.Type) {
+		fmt.Fprintf(hash, "%d:%d:%d(", rtype.Kind(), rtype.Size(), rtype.Align())
+		switch rtype.Kind() {
+		case reflect.Array:
+			fmt.Fprintf(hash, "%d,", rtype.Len())
+			write(rtype.Elem())
+		case reflect.Struct:
+			for i := range rtype.NumField() {
+				fmt.Fprintf(hash, "%d,", rtype.Field(i).Offset)
+				write(rtype.Field(i).Type)
+			}
+		}
+		hash.Write([]byte{')'})
+	}
+	write(rtype)
+	return hash.Sum64()
+}
+
+// File of the queue, to pass to another process, see [OpenSharedQueue].
+func (q *SharedQueue[T]) File() FileDescriptor { return q.fd }
+
+// Len returns the number of values in the queue.
+func (q *SharedQueue[T]) Len() int {
+	return int(atomic.LoadUint32(&q.header.Head) - atomic.LoadUint32(&q.header.Tail))
+}
+
+// Cap returns the number of values that the queue can hold.
+func (q *SharedQueue[T]) Cap() int { return len(q.slots) }
+
+// Send value to the queue, waiting while it is full, until ctx is done. The
+// queue must have been created or opened as [SharedQueueSender], otherwise
+// [WriteError.BadFile] is returned.
+func (q *SharedQueue[T]) Send(ctx context.Context, value T) error {
+	var h = q.header
+	if !q.sender {
+		return new(WriteError).Types().BadFile
+	}
+	q.watch(&h.Receiver)
+	for {
+		if atomic.LoadUint32(&h.ReceiverClosed) != 0 {
+			return new(WriteError).Types().BrokenPipe
+		}
+		var head, tail = atomic.LoadUint32(&h.Head), atomic.LoadUint32(&h.Tail)
+		if head-tail <= q.mask {
+			q.slots[head&q.mask] = value
+			atomic.StoreUint32(&h.Head, head+1)
+			if atomic.LoadUint32(&h.HeadWaiters) != 0 {
+				q.linux.WakeFutex(&h.Head, math.MaxInt32, 0)
+			}
+			return nil
+		}
+		if q.exited(&h.Receiver) {
+			return new(WriteError).Types().BrokenPipe
+		}
+		if err := q.wait(ctx, "Send", &h.Tail, &h.TailWaiters, tail); err != nil {
+			return err
+		}
+	}
+}
+
+// Receive a value from the queue, waiting while it is empty, until ctx is
+// done. The queue must have been created or opened as [SharedQueueReceiver],
+// otherwise [ReadError.BadFile] is returned.
+func (q *SharedQueue[T]) Receive(ctx context.Context) (T, error) {
+	var h = q.header
+	if !q.receiver {
+		var zero T
+		return zero, new(ReadError).Types().BadFile
+	}
+	q.watch(&h.Sender)
+	for {
+		var tail = atomic.LoadUint32(&h.Tail)
+		if atomic.LoadUint32(&h.Head) != tail {
+			var value = q.slots[tail&q.mask]
+			atomic.StoreUint32(&h.Tail, tail+1)
+			if atomic.LoadUint32(&h.TailWaiters) != 0 {
+				q.linux.WakeFutex(&h.Tail, math.MaxInt32, 0)
+			}
+			return value, nil
+		}
+		if atomic.LoadUint32(&h.SenderClosed) != 0 || q.exited(&h.Sender) {
+			if atomic.LoadUint32(&h.Head) == tail {
+				var zero T
+				return zero, io.EOF
+			}
+			continue
+		}
+		if err := q.wait(ctx, "Receive", &h.Head, &h.HeadWaiters, tail); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+}
+
+// wait for the futex at addr to change from value, for at most the interval
+// at which the other end is checked, or until ctx is done.
+func (q *SharedQueue[T]) wait(ctx context.Context, operation string, addr, waiters *uint32, value uint32) error {
+	atomic.AddUint32(waiters, 1)
+	defer atomic.AddUint32(waiters, ^uint32(0))
+	return waitFutex(ctx, q.linux, operation, addr, value, sharedQueueInterval)
+}
+
+// watch the process at the other end, recorded at pid, by opening a pidfd for
+// it as soon as its ID is first seen, when the end is taken or on the next
+// send or receive, so that it is not confused with a process that reuses the
+// ID after it exits. The pidfd is a kernel descriptor, whatever the [API] of
+// the queue, so it is opened, polled and closed with native calls.
+func (q *SharedQueue[T]) watch(pid *int32) {
+	var id = atomic.LoadInt32(pid)
+	if id == 0 || id == q.peerID || int(id) == syscall.Getpid() {
+		return
+	}
+	if q.peer >= 0 {
+		syscall.Close(int(q.peer))
+	}
+	var errno syscall.Errno
+	q.peer, errno = openProcess(int(id))
+	q.peerID, q.peerExited = id, errno == syscall.ESRCH
+}
+
+// exited reports whether the process at the other end, recorded at pid, has
+// exited, through the pidfd opened by [SharedQueue.watch]. A process that
+// exits, and whose ID is reused, before this end first sees its ID, is not
+// detected.
+func (q *SharedQueue[T]) exited(pid *int32) bool {
+	q.watch(pid)
+	if q.peerExited {
+		return true
+	}
+	if q.peer < 0 {
+		return false
+	}
+	var files = []FileToPoll{{File: q.peer, Notify: PollHasReadAvailable}}
+	n, errno := poll(files, 0)
+	return errno == 0 && n > 0 && files[0].Result&PollHasReadAvailable != 0
+}
+
+// Close the end of the queue, which wakes the other end, then unmap the queue
+// and close its file.
+func (q *SharedQueue[T]) Close() error {
+	if q.closed.Swap(true) {
+		return nil
+	}
+	var h = q.header
+	if q.sender {
+		atomic.StoreUint32(&h.SenderClosed, 1)
+		q.linux.WakeFutex(&h.Head, math.MaxInt32, 0)
+	}
+	if q.receiver {
+		atomic.StoreUint32(&h.ReceiverClosed, 1)
+		q.linux.WakeFutex(&h.Tail, math.MaxInt32, 0)
+	}
+	if q.peer >= 0 {
+		syscall.Close(int(q.peer))
+	}
+	q.mapped.Close()
+	return q.linux.Close(q.fd)
+}
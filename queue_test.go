@@ -0,0 +1,237 @@
+package linux_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"structs"
+	"syscall"
+	"testing"
+	"time"
+
+	"verbose.style/linux"
+)
+
+type queueMessage struct {
+	_ structs.HostLayout
+
+	Sequence uint64
+	Payload  [16]byte
+}
+
+type queueOther struct {
+	_ structs.HostLayout
+
+	Sequence uint32
+	Payload  [20]byte
+}
+
+// openQueue opens another end of q, as another process would.
+func openQueue[T any](t *testing.T, api *linux.API, fd linux.FileDescriptor, end linux.SharedQueueEnd) *linux.SharedQueue[T] {
+	t.Helper()
+	dup, err := syscall.Dup(int(fd))
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, err := linux.OpenSharedQueue[T](api, linux.FileDescriptor(dup), end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return q
+}
+
+func TestSharedQueue(t *testing.T) {
+	var api = linux.Native()
+	var ctx = context.Background()
+	sender, err := linux.NewSharedQueue[queueMessage](api, 3, linux.SharedQueueSender)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sender.Close()
+	if sender.Cap() != 4 {
+		t.Fatalf("expected a capacity of 4, got %d", sender.Cap())
+	}
+	var receiver = openQueue[queueMessage](t, api, sender.File(), linux.SharedQueueReceiver)
+	defer receiver.Close()
+	if _, err := sender.Receive(ctx); !errors.Is(err, new(linux.ReadError).Types().BadFile) {
+		t.Fatalf("expected the sender to not receive, got %v", err)
+	}
+	if err := receiver.Send(ctx, queueMessage{}); !errors.Is(err, new(linux.WriteError).Types().BadFile) {
+		t.Fatalf("expected the receiver to not send, got %v", err)
+	}
+
+	var received = make(chan uint64, 10)
+	go func() {
+		defer close(received)
+		for {
+			message, err := receiver.Receive(ctx)
+			if err != nil {
+				if err != io.EOF {
+					t.Error(err)
+				}
+				return
+			}
+			received <- message.Sequence
+		}
+	}()
+	for i := range uint64(10) {
+		if err := sender.Send(ctx, queueMessage{Sequence: i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := range uint64(10) {
+		if sequence := <-received; sequence != i {
+			t.Fatalf("received %d, expected %d", sequence, i)
+		}
+	}
+	sender.Close()
+	if _, ok := <-received; ok {
+		t.Fatal("expected the receiver to stop once the sender closed")
+	}
+}
+
+func TestSharedQueueBlocking(t *testing.T) {
+	var api = linux.Native()
+	q, err := linux.NewSharedQueue[queueMessage](api, 1, linux.SharedQueueSender|linux.SharedQueueReceiver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.Receive(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if err := q.Send(context.Background(), queueMessage{Sequence: 1}); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel = context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	if err := q.Send(ctx, queueMessage{Sequence: 2}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected canceled, got %v", err)
+	}
+	var receiver = openQueue[queueMessage](t, api, q.File(), linux.SharedQueueReceiver)
+	receiver.Receive(context.Background())
+	receiver.Close()
+	if err := q.Send(context.Background(), queueMessage{Sequence: 3}); !errors.Is(err, new(linux.WriteError).Types().BrokenPipe) {
+		t.Fatalf("expected broken pipe, got %v", err)
+	}
+}
+
+func TestSharedQueueLayout(t *testing.T) {
+	var api = linux.Native()
+	q, err := linux.NewSharedQueue[queueMessage](api, 8, linux.SharedQueueSender)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q.Close()
+	dup, err := syscall.Dup(int(q.File()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var view *linux.ViewError
+	if _, err := linux.OpenSharedQueue[queueOther](api, linux.FileDescriptor(dup), linux.SharedQueueReceiver); !errors.As(err, &view) {
+		t.Fatalf("expected a layout mismatch, got %v", err)
+	}
+	if err := syscall.Close(dup); err != syscall.EBADF {
+		t.Fatalf("expected the queue to close the file, got %v", err)
+	}
+	if _, err := linux.NewSharedQueue[*queueMessage](api, 8, linux.SharedQueueSender); !errors.As(err, &view) {
+		t.Fatalf("expected pointers to be rejected, got %v", err)
+	}
+}
+
+func TestSharedQueueAcrossProcesses(t *testing.T) {
+	if mode := os.Getenv("LINUX_TEST_QUEUE"); mode != "" {
+		q, err := linux.OpenSharedQueue[queueMessage](linux.Native(), 3, linux.SharedQueueSender)
+		if err != nil {
+			os.Exit(1)
+		}
+		if mode == "silent" || mode == "reaped" {
+			os.Exit(0) // before sending anything.
+		}
+		for i := range uint64(100) {
+			if err := q.Send(context.Background(), queueMessage{Sequence: i}); err != nil {
+				os.Exit(2)
+			}
+		}
+		os.Exit(0) // without closing, as if the process crashed.
+	}
+	for _, mode := range []string{"crash", "silent", "reaped"} {
+		t.Run(mode, func(t *testing.T) {
+			var q, cmd = startQueueSender(t, mode)
+			defer q.Close()
+			var expected uint64 = 100
+			if mode != "crash" {
+				expected = 0
+			}
+			if mode == "reaped" {
+				// the sender is gone before the receiver first sees its ID.
+				if err := cmd.Wait(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			receiveQueue(t, q, cmd, expected)
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			if _, err := q.Receive(ctx); err != io.EOF {
+				t.Fatalf("expected EOF once the sender has exited, got %v", err)
+			}
+		})
+	}
+}
+
+// startQueueSender starts a process that opens the sending end of a new
+// queue, in the given mode of [TestSharedQueueAcrossProcesses].
+func startQueueSender(t *testing.T, mode string) (*linux.SharedQueue[queueMessage], *exec.Cmd) {
+	t.Helper()
+	q, err := linux.NewSharedQueue[queueMessage](linux.Native(), 16, linux.SharedQueueReceiver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd, err := syscall.Dup(int(q.File()))
+	if err != nil {
+		q.Close()
+		t.Fatal(err)
+	}
+	var cmd = exec.Command(os.Args[0], "-test.run=^TestSharedQueueAcrossProcesses$")
+	cmd.Env = append(os.Environ(), "LINUX_TEST_QUEUE="+mode)
+	cmd.ExtraFiles = []*os.File{os.NewFile(uintptr(fd), "queue")}
+	if err := cmd.Start(); err != nil {
+		q.Close()
+		t.Fatal(err)
+	}
+	cmd.ExtraFiles[0].Close()
+	return q, cmd
+}
+
+// receiveQueue receives from q until the sender has exited, expecting the
+// given number of messages in sequence.
+func receiveQueue(t *testing.T, q *linux.SharedQueue[queueMessage], cmd *exec.Cmd, expected uint64) {
+	t.Helper()
+	var next uint64
+	for {
+		message, err := q.Receive(context.Background())
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if message.Sequence != next {
+			t.Fatalf("received %d, expected %d", message.Sequence, next)
+		}
+		next++
+	}
+	if next != expected {
+		t.Fatalf("received %d messages before the sender exited, expected %d", next, expected)
+	}
+	if cmd.ProcessState != nil {
+		return
+	}
+	if err := cmd.Wait(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,301 @@
+package linux
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+// Record returns an [API] that writes a log of every call made through it to
+// w, as JSON Lines, such that the calls can be served again by [Replay]. The
+// log includes the arguments, the bytes read, the results and the errors of
+// each call, in the order that the calls complete. Pointers are not
+// deterministic between processes, so the log only records whether they are nil
+// and mapped memory is logged by its contents. Errors are logged by their type
+// and errno, without any wrapping such as [OperationError].
+func Record(api *API, w io.Writer) *API {
+	var recorder = new(API)
+	var mu sync.Mutex
+	var encoder = json.NewEncoder(w)
+	var inner = reflect.ValueOf(api).Elem()
+	var outer = reflect.ValueOf(recorder).Elem()
+	for i := range outer.NumField() {
+		var field = outer.Type().Field(i)
+		var fn = inner.Field(i)
+		if fn.IsNil() {
+			continue
+		}
+		outer.Field(i).Set(reflect.MakeFunc(field.Type, func(args []reflect.Value) []reflect.Value {
+			var entry = recording{Call: field.Name}
+			for i, arg := range args {
+				entry.Args = append(entry.Args, recordArg(field.Name, i, arg))
+			}
+			var results = fn.Call(args)
+			if i, ok := recordOutputs[field.Name]; ok {
+				var output = args[i]
+				if n := results[0]; output.Type() == reflect.TypeFor[[]byte]() && n.CanInt() && n.Int() >= 0 && n.Int() <= int64(output.Len()) {
+					output = output.Slice(0, int(n.Int()))
+				}
+				entry.Data = recordValue(output)
+			}
+			for i, result := range results {
+				if result.Type() == reflect.TypeFor[error]() {
+					if err, ok := result.Interface().(error); ok {
+						entry.Error = recordError(err)
+					}
+					continue
+				}
+				if result.Type() == reflect.TypeFor[File]() {
+					results[i] = rebind(result, api, recorder)
+				}
+				entry.Results = append(entry.Results, recordValue(result))
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			encoder.Encode(entry)
+			return results
+		}))
+	}
+	return recorder
+}
+
+// Replay returns an [API] that serves the calls logged by [Record] back in the
+// same order, without making any system calls. A call that does not match the
+// next call in the log fails with a [ReplayError]. Mapped memory is backed by a
+// copy of the logged contents, [API.Remap] moves that copy and the [API.Heap]
+// is simulated, a call that cannot be simulated fails with a [ReplayError].
+func Replay(log io.Reader) *API {
+	var replayer = new(API)
+	var mu sync.Mutex
+	var mem = newMemory()
+	var decoder = json.NewDecoder(log)
+	var index int
+	var outer = reflect.ValueOf(replayer).Elem()
+	for i := range outer.NumField() {
+		var field = outer.Type().Field(i)
+		outer.Field(i).Set(reflect.MakeFunc(field.Type, func(args []reflect.Value) []reflect.Value {
+			mu.Lock()
+			defer mu.Unlock()
+			index++
+			var results = make([]reflect.Value, field.Type.NumOut())
+			var fail = func(reason string) []reflect.Value {
+				for i := range results {
+					results[i] = reflect.Zero(field.Type.Out(i))
+				}
+				var err error = &ReplayError{Index: index, Call: field.Name, Reason: reason}
+				results[len(results)-1] = reflect.ValueOf(&err).Elem()
+				return results
+			}
+			var entry recording
+			if err := decoder.Decode(&entry); err != nil {
+				if err == io.EOF {
+					return fail("is past the end of the log")
+				}
+				return fail(err.Error())
+			}
+			if entry.Call != field.Name {
+				return fail("was expected to be a call to " + entry.Call)
+			}
+			for i, arg := range args {
+				if got := recordArg(field.Name, i, arg); i >= len(entry.Args) || !bytes.Equal(got, entry.Args[i]) {
+					return fail(fmt.Sprintf("has argument %d %s, expected %s", i, got, entry.Args[i:min(i+1, len(entry.Args))]))
+				}
+			}
+			if i, ok := recordOutputs[field.Name]; ok {
+				var data = reflect.New(args[i].Type())
+				if err := json.Unmarshal(entry.Data, data.Interface()); err != nil {
+					return fail(err.Error())
+				}
+				reflect.Copy(args[i], data.Elem())
+			}
+			var next int
+			for i := range results {
+				var rtype = field.Type.Out(i)
+				if rtype == reflect.TypeFor[error]() {
+					var err = replayError(entry.Error)
+					results[i] = reflect.ValueOf(&err).Elem()
+					continue
+				}
+				if next >= len(entry.Results) {
+					return fail("is missing results")
+				}
+				var value reflect.Value
+				var err error
+				if rtype == reflect.TypeFor[unsafe.Pointer]() {
+					value, err = replayPointer(mem, field.Name, args, entry.Results[next])
+				} else {
+					value, err = replayValue(mem, replayer, rtype, entry.Results[next])
+				}
+				if err != nil {
+					return fail(err.Error())
+				}
+				results[i] = value
+				next++
+			}
+			return results
+		}))
+	}
+	return replayer
+}
+
+// ReplayError is returned by an [API] returned by [Replay], when a call diverges
+// from the log.
+type ReplayError struct {
+	Index  int    // index of the call, starting at 1.
+	Call   string // name of the [API] function that was called.
+	Reason string // description of the divergence.
+}
+
+func (e *ReplayError) Error() string {
+	return fmt.Sprintf("replay: call %d to %s %s", e.Index, e.Call, e.Reason)
+}
+
+// recording is a single line of the log written by [Record].
+type recording struct {
+	Call    string            `json:"call"`
+	Args    []json.RawMessage `json:"args"`
+	Data    json.RawMessage   `json:"data,omitempty"`
+	Results []json.RawMessage `json:"results"`
+	Error   *recordedError    `json:"error,omitempty"`
+}
+
+type recordedError struct {
+	Type  string        `json:"type,omitempty"`
+	Errno syscall.Errno `json:"errno,omitempty"`
+	Text  string        `json:"text"`
+}
+
+type recordedMemory struct {
+	Len  int    `json:"len"`
+	Data []byte `json:"data"`
+}
+
+// recordOutputs are the indices of the arguments that are filled in by
+// each [API] function.
+var recordOutputs = map[string]int{
+	"Read":            1,
+	"Poll":            0,
+	"ReadDirectory":   1,
+	"MemoryResidency": 2,
+}
+
+func recordArg(name string, i int, arg reflect.Value) json.RawMessage {
+	if output, ok := recordOutputs[name]; ok && output == i && arg.Type() == reflect.TypeFor[[]byte]() {
+		return recordJSON(arg.Len())
+	}
+	return recordValue(arg)
+}
+
+func recordValue(value reflect.Value) json.RawMessage {
+	switch v := value.Interface().(type) {
+	case File:
+		return recordJSON(v.Descriptor)
+	case MappedMemory:
+		var memory = recordedMemory{Len: v.Len()}
+		var data = make([]byte, v.Len())
+		if _, err := v.ReadAt(data, 0); err == nil {
+			memory.Data = data
+		}
+		return recordJSON(memory)
+	case unsafe.Pointer:
+		return recordJSON(v != nil)
+	}
+	return recordJSON(value.Interface())
+}
+
+func recordJSON(value any) json.RawMessage {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return recordJSON(err.Error())
+	}
+	return data
+}
+
+func recordError(err error) *recordedError {
+	var recorded = recordedError{Text: err.Error()}
+	if errno, ok := err.(syscall.Errno); ok {
+		recorded.Errno = errno
+	}
+	// typed errors are recorded by their type, even when wrapped, for
+	// example by [Annotate].
+	for ; err != nil; err = errors.Unwrap(err) {
+		typed, ok := err.(interface{ Errno() syscall.Errno })
+		if _, generated := errorTypes[reflect.TypeOf(err).Name()]; ok && generated {
+			recorded.Type = reflect.TypeOf(err).Name()
+			recorded.Errno = typed.Errno()
+			break
+		}
+	}
+	return &recorded
+}
+
+func replayError(recorded *recordedError) error {
+	switch {
+	case recorded == nil:
+		return nil
+	case errorTypes[recorded.Type] != nil:
+		return errorTypes[recorded.Type].parse(recorded.Errno)
+	case recorded.Errno != 0:
+		return recorded.Errno
+	default:
+		return errors.New(recorded.Text)
+	}
+}
+
+func replayValue(mem *memory, replayer *API, rtype reflect.Type, raw json.RawMessage) (reflect.Value, error) {
+	switch rtype {
+	case reflect.TypeFor[File]():
+		var file = reflect.New(rtype).Elem()
+		file.FieldByName("Linux").Set(reflect.ValueOf(replayer))
+		return file, json.Unmarshal(raw, file.FieldByName("Descriptor").Addr().Interface())
+	case reflect.TypeFor[MappedMemory]():
+		var memory *recordedMemory
+		if err := json.Unmarshal(raw, &memory); err != nil || memory == nil || memory.Len == 0 {
+			return reflect.Zero(rtype), err
+		}
+		var slice = make([]byte, memory.Len)
+		copy(slice, memory.Data)
+		var mapped = &memoryMap{prot: MemoryAllowReads | MemoryAllowWrites, slice: slice, mem: mem}
+		mem.mu.Lock()
+		mem.maps[mapped.UnsafePointer()] = mapped
+		mem.mu.Unlock()
+		var memoryMapped MappedMemory = mapped
+		return reflect.ValueOf(&memoryMapped).Elem(), nil
+	}
+	var value = reflect.New(rtype)
+	return value.Elem(), json.Unmarshal(raw, value.Interface())
+}
+
+// replayPointer makes the call again on mem, as only whether the pointer was
+// nil is logged, such that the pointer is into the simulated heap or into the
+// mapped memory backed by the logged contents.
+func replayPointer(mem *memory, call string, args []reflect.Value, raw json.RawMessage) (reflect.Value, error) {
+	var notNil bool
+	if err := json.Unmarshal(raw, &notNil); err != nil || !notNil {
+		return reflect.Zero(reflect.TypeFor[unsafe.Pointer]()), err
+	}
+	var ptr unsafe.Pointer
+	var err error
+	switch call {
+	case "Heap":
+		ptr, err = mem.heap(args[0].Interface().(unsafe.Pointer))
+	case "Remap":
+		var mapped *memoryMap
+		mapped, err = mem.remap(args[0].Interface().(unsafe.Pointer), int(args[1].Int()), int(args[2].Int()), args[3].Interface().(Remap), args[4].Interface().(unsafe.Pointer))
+		if err == nil {
+			ptr = mapped.UnsafePointer()
+		}
+	default:
+		return reflect.Value{}, errors.New("returns a pointer that cannot be replayed")
+	}
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("returns a pointer that cannot be replayed: %w", err)
+	}
+	return reflect.ValueOf(ptr), nil
+}
@@ -0,0 +1,113 @@
+package linux_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"unsafe"
+
+	"verbose.style/linux"
+)
+
+func TestRecordReplay(t *testing.T) {
+	var log bytes.Buffer
+	var run = func(Linux *linux.API) ([]byte, error) {
+		f, err := Linux.Open("/file", linux.FileAccessReadWrite, linux.FileCreateIfNeeded, 0, 0600)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		if _, err := f.Write([]byte("hello world")); err != nil {
+			return nil, err
+		}
+		if _, err := f.Seek(6, 0); err != nil {
+			return nil, err
+		}
+		var buf = make([]byte, 16)
+		n, err := f.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := Linux.Stat("/missing"); err != new(linux.StatError).Types().DoesNotExist {
+			return nil, err
+		}
+		return buf[:n], nil
+	}
+	recorded, err := run(linux.Record(linux.Memory(), &log))
+	if err != nil || string(recorded) != "world" {
+		t.Fatal(string(recorded), err)
+	}
+
+	replayed, err := run(linux.Replay(bytes.NewReader(log.Bytes())))
+	if err != nil || !bytes.Equal(recorded, replayed) {
+		t.Fatal(string(replayed), err)
+	}
+
+	var Linux = linux.Replay(bytes.NewReader(log.Bytes()))
+	_, err = Linux.Open("/other", linux.FileAccessReadWrite, linux.FileCreateIfNeeded, 0, 0600)
+	var diverged *linux.ReplayError
+	if !errors.As(err, &diverged) || diverged.Index != 1 {
+		t.Fatal(err)
+	}
+}
+
+func TestReplayPointers(t *testing.T) {
+	var log bytes.Buffer
+	var run = func(Linux *linux.API) (string, error) {
+		f, err := Linux.Open("/file", linux.FileAccessReadWrite, linux.FileCreateIfNeeded, 0, 0600)
+		if err != nil {
+			return "", err
+		}
+		defer f.Close()
+		if _, err := f.Write([]byte("hello")); err != nil {
+			return "", err
+		}
+		mapped, err := Linux.MapIntoMemory(nil, 5, linux.MemoryAllowReads, linux.MapPrivate, 0, f.Descriptor, 0)
+		if err != nil {
+			return "", err
+		}
+		moved, err := Linux.Remap(mapped.UnsafePointer(), 5, 4096, linux.RemapMayMove, nil)
+		if err != nil {
+			return "", err
+		}
+		heap, err := Linux.Heap(nil)
+		if err != nil {
+			return "", err
+		}
+		grown, err := Linux.Heap(unsafe.Add(heap, 64))
+		if err != nil {
+			return "", err
+		}
+		if grown != unsafe.Add(heap, 64) {
+			return "", errors.New("heap did not grow")
+		}
+		return string(unsafe.Slice((*byte)(moved), 5)), nil
+	}
+	if recorded, err := run(linux.Record(linux.Memory(), &log)); err != nil || recorded != "hello" {
+		t.Fatal(recorded, err)
+	}
+	if replayed, err := run(linux.Replay(bytes.NewReader(log.Bytes()))); err != nil || replayed != "hello" {
+		t.Fatalf("%q %v", replayed, err)
+	}
+}
+
+func TestRecordErrorTypes(t *testing.T) {
+	var log bytes.Buffer
+	var run = func(Linux *linux.API) (err1, err2 error) {
+		_, err1 = Linux.Stat("/missing")
+		_, err2 = Linux.Sleep(linux.ClockMonotonic, 0, linux.TimeFromDuration(-1))
+		return err1, err2
+	}
+	var recorder = linux.Record(linux.Annotate(linux.Memory()), &log)
+	if stat, sleep := run(recorder); stat == nil || sleep == nil {
+		t.Fatal(stat, sleep)
+	}
+	var Linux = linux.Replay(bytes.NewReader(log.Bytes()))
+	stat, sleep := run(Linux)
+	if stat != new(linux.StatError).Types().DoesNotExist {
+		t.Fatalf("expected StatError.DoesNotExist, got %#v", stat)
+	}
+	if sleep != new(linux.SleepError).Types().Invalid {
+		t.Fatalf("expected SleepError.Invalid, got %#v", sleep)
+	}
+}
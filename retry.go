@@ -0,0 +1,142 @@
+package linux
+
+import (
+	"errors"
+	"syscall"
+	"time"
+)
+
+// Retrying returns an [API] that transparently retries any call that was
+// interrupted by a signal, following the retry semantics of each system call:
+//
+//   - [API.Read] and [API.Write] are retried until they complete or fail.
+//   - [API.Open] is retried, as opening a FIFO or device can block.
+//   - [API.Poll] is retried with the remaining timeout, once it has elapsed the
+//     call reports that no files are ready.
+//   - [API.Truncate] is retried.
+//   - [API.WaitFutexBitset] and [API.WaitFutexes] are retried, as their
+//     deadlines are absolute. [API.WaitFutex] is not, as futex waits can wake
+//     up spuriously anyway, so callers already check the futex again.
+//   - [API.Sleep] is retried for the remaining duration, or until the same
+//     time with [SleepUntil].
+//   - [API.CreateEvent] and [API.CreateMemoryFile] cannot be interrupted, but
+//     like [API.Open], they return a [File] that retries its reads and writes.
+//   - [API.Close] is never retried, as Linux always releases the descriptor, even
+//     when interrupted, so a retry could close a descriptor that has since been
+//     reused by another goroutine. An interrupted close is reported as success.
+//
+// All other calls, and those that api does not implement, are passed through
+// to api unchanged.
+func Retrying(api *API) *API {
+	var retry = new(API)
+	*retry = *api
+	if api.Read != nil {
+		retry.Read = func(fd FileDescriptor, buf []byte) (Bytes, error) {
+			for {
+				n, err := api.Read(fd, buf)
+				if !errors.Is(err, syscall.EINTR) {
+					return n, err
+				}
+			}
+		}
+	}
+	if api.Write != nil {
+		retry.Write = func(fd FileDescriptor, buf []byte) (Bytes, error) {
+			for {
+				n, err := api.Write(fd, buf)
+				if !errors.Is(err, syscall.EINTR) {
+					return n, err
+				}
+			}
+		}
+	}
+	if api.Open != nil {
+		retry.Open = func(name Path, mode FileAccessMode, flag FileCreationFlags, status FileStatusFlags, perm FilePermissions) (File, error) {
+			for {
+				file, err := api.Open(name, mode, flag, status, perm)
+				if !errors.Is(err, syscall.EINTR) {
+					return File{Linux: retry, Descriptor: file.Descriptor}, err
+				}
+			}
+		}
+	}
+	if api.Close != nil {
+		retry.Close = func(fd FileDescriptor) error {
+			if err := api.Close(fd); !errors.Is(err, syscall.EINTR) {
+				return err
+			}
+			return nil
+		}
+	}
+	if api.Poll != nil {
+		retry.Poll = func(files []FileToPoll, timeout time.Duration) (int, error) {
+			var deadline = time.Now().Add(timeout)
+			for {
+				i, err := api.Poll(files, timeout)
+				if !errors.Is(err, syscall.EINTR) {
+					return i, err
+				}
+				if timeout < 0 {
+					continue
+				}
+				if timeout = time.Until(deadline); timeout <= 0 {
+					return 0, nil
+				}
+			}
+		}
+	}
+	if api.CreateEvent != nil {
+		retry.CreateEvent = func(initial uint32, flags EventFlags) (File, error) {
+			file, err := api.CreateEvent(initial, flags)
+			return File{Linux: retry, Descriptor: file.Descriptor}, err
+		}
+	}
+	if api.CreateMemoryFile != nil {
+		retry.CreateMemoryFile = func(name Path, flags MemoryFileFlags) (File, error) {
+			file, err := api.CreateMemoryFile(name, flags)
+			return File{Linux: retry, Descriptor: file.Descriptor}, err
+		}
+	}
+	if api.Truncate != nil {
+		retry.Truncate = func(fd FileDescriptor, size int64) error {
+			for {
+				if err := api.Truncate(fd, size); !errors.Is(err, syscall.EINTR) {
+					return err
+				}
+			}
+		}
+	}
+	if api.WaitFutexBitset != nil {
+		retry.WaitFutexBitset = func(addr *uint32, value uint32, deadline *Time, bitset uint32, flags FutexFlags) error {
+			for {
+				if err := api.WaitFutexBitset(addr, value, deadline, bitset, flags); !errors.Is(err, syscall.EINTR) {
+					return err
+				}
+			}
+		}
+	}
+	if api.WaitFutexes != nil {
+		retry.WaitFutexes = func(futexes []FutexToWait, deadline *Time, flags FutexFlags) (int, error) {
+			for {
+				i, err := api.WaitFutexes(futexes, deadline, flags)
+				if !errors.Is(err, syscall.EINTR) {
+					return i, err
+				}
+			}
+		}
+	}
+	if api.Sleep != nil {
+		retry.Sleep = func(clock Clock, flags Sleep, t Time) (Time, error) {
+			for {
+				remaining, err := api.Sleep(clock, flags, t)
+				if !errors.Is(err, syscall.EINTR) {
+					return remaining, err
+				}
+				if flags&SleepUntil == 0 {
+					t = remaining
+				}
+			}
+		}
+	}
+	return retry
+}
@@ -0,0 +1,106 @@
+package linux_test
+
+import (
+	"testing"
+	"time"
+
+	"verbose.style/linux"
+)
+
+func TestRetrying(t *testing.T) {
+	var interrupts int
+	var closes, opens int
+	var timeouts []time.Duration
+	var api = linux.Retrying(&linux.API{
+		Open: func(name linux.Path, mode linux.FileAccessMode, flag linux.FileCreationFlags, status linux.FileStatusFlags, perm linux.FilePermissions) (linux.File, error) {
+			if opens++; opens < 3 {
+				return linux.File{Descriptor: -1}, new(linux.OpenError).Types().Interrupted
+			}
+			return linux.File{Descriptor: 7}, nil
+		},
+		Read: func(fd linux.FileDescriptor, buf []byte) (linux.Bytes, error) {
+			if interrupts++; interrupts < 3 {
+				return 0, new(linux.ReadError).Types().Interrupted
+			}
+			return linux.Bytes(copy(buf, "ok")), nil
+		},
+		Write: func(fd linux.FileDescriptor, buf []byte) (linux.Bytes, error) {
+			return 0, new(linux.WriteError).Types().NoMoreSpace
+		},
+		Close: func(fd linux.FileDescriptor) error {
+			closes++
+			return new(linux.CloseError).Types().Interrupted
+		},
+		Poll: func(files []linux.FileToPoll, timeout time.Duration) (int, error) {
+			timeouts = append(timeouts, timeout)
+			time.Sleep(20 * time.Millisecond)
+			return 0, new(linux.PollError).Types().Interrupted
+		},
+	})
+
+	var buf [2]byte
+	n, err := api.Read(0, buf[:])
+	if err != nil || string(buf[:n]) != "ok" || interrupts != 3 {
+		t.Fatal(n, err, interrupts)
+	}
+	if _, err := api.Write(0, buf[:]); err != new(linux.WriteError).Types().NoMoreSpace {
+		t.Fatal(err)
+	}
+	if err := api.Close(0); err != nil || closes != 1 {
+		t.Fatal(err, closes)
+	}
+	file, err := api.Open("/fifo", linux.FileAccessReadOnly, 0, 0, 0)
+	if err != nil || file.Descriptor != 7 || file.Linux != api || opens != 3 {
+		t.Fatal(file.Descriptor, err, opens)
+	}
+	if api.Truncate != nil || api.CreateEvent != nil || api.Sleep != nil || api.WaitFutexes != nil {
+		t.Fatal("calls that the inner API does not implement should stay nil")
+	}
+	i, err := api.Poll(make([]linux.FileToPoll, 1), 50*time.Millisecond)
+	if i != 0 || err != nil {
+		t.Fatal(i, err)
+	}
+	if len(timeouts) < 2 || timeouts[0] != 50*time.Millisecond {
+		t.Fatal(timeouts)
+	}
+	for i := 1; i < len(timeouts); i++ {
+		if timeouts[i] >= timeouts[i-1] {
+			t.Fatal("poll timeout was not reduced", timeouts)
+		}
+	}
+}
+
+func TestRetryingAnnotated(t *testing.T) {
+	var api = linux.Retrying(linux.Annotate(linux.Faulty(linux.Memory(), linux.FaultPlan{
+		Faults: []linux.Fault{
+			{Call: "Write", Nth: 1, Err: new(linux.WriteError).Types().Interrupted},
+			{Call: "Read", Nth: 1, Err: new(linux.ReadError).Types().Interrupted},
+			{Call: "Truncate", Nth: 1, Err: new(linux.TruncateError).Types().Interrupted},
+			{Call: "Sleep", Nth: 1, Err: new(linux.SleepError).Types().Interrupted},
+			{Call: "Close", Err: new(linux.CloseError).Types().Interrupted},
+		},
+	})))
+	f, err := api.Open("/file", linux.FileAccessReadWrite, linux.FileCreateIfNeeded, 0, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := f.Write([]byte("ok")); n != 2 || err != nil {
+		t.Fatal(n, err)
+	}
+	if err := api.Truncate(f.Descriptor, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Seek(0, 0); err != nil {
+		t.Fatal(err)
+	}
+	var buf [2]byte
+	if n, err := f.Read(buf[:]); n != 1 || err != nil {
+		t.Fatal(n, err)
+	}
+	if _, err := api.Sleep(linux.ClockMonotonic, 0, linux.TimeFromDuration(time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal("interrupted close was reported", err)
+	}
+}
@@ -0,0 +1,215 @@
+package linux
+
+import (
+	"io"
+	"structs"
+	"sync/atomic"
+	"syscall"
+	"unsafe"
+)
+
+// Ring is a single-producer, single-consumer ring of bytes, in a memory file
+// that can be shared with other processes by passing [Ring.File] to them, see
+// [OpenRing]. The data of the ring is mapped twice, back to back, so that
+// reads and writes never have to wrap around. Reads and writes never block,
+// instead they fail with [ReadError.WouldBlock] and [WriteError.WouldBlock]
+// when the ring is empty or full, as for files opened with [FileNonBlocking].
+// A ring can be read by one goroutine and written by another.
+type Ring struct {
+	linux  *API
+	fd     FileDescriptor
+	header MappedMemory
+	data   [2]MappedMemory
+	buf    []byte // data, mapped twice.
+	head   *atomic.Uint64
+	tail   *atomic.Uint64
+	closed *atomic.Uint32
+	size   uint64
+	done   atomic.Bool // set once the ring is closed.
+}
+
+// ringHeader is the first page of the memory file of a [Ring], the head and
+// tail are on their own cache lines, as they are written by different
+// processes.
+type ringHeader struct {
+	_ structs.HostLayout
+
+	Magic   uint32
+	Version uint32
+	Size    uint64 // of the data, which follows the header page.
+	Closed  uint32 // set once the writer has closed, see [Ring.CloseWrite].
+	_       [44]byte
+	Head    uint64 // total number of bytes written.
+	_       [56]byte
+	Tail    uint64 // total number of bytes read.
+}
+
+const (
+	ringMagic   = 0x676e6952 // "Ring"
+	ringVersion = 1
+)
+
+// NewRing creates a [Ring] that can hold size bytes, rounded up to a multiple
+// of the page size. The api must honor [MapExactAddress], which [Memory] does
+// not.
+func NewRing(api *API, size int) (*Ring, error) {
+	var page = syscall.Getpagesize()
+	if size <= 0 {
+		return nil, new(MapError).Types().Invalid
+	}
+	size = (size + page - 1) &^ (page - 1)
+	file, err := api.CreateMemoryFile("ring", MemoryFileCloseOnExecute)
+	if err != nil {
+		return nil, err
+	}
+	if err := api.Truncate(file.Descriptor, int64(page+size)); err != nil {
+		api.Close(file.Descriptor)
+		return nil, err
+	}
+	ring, err := mapRing(api, file.Descriptor, size)
+	if err != nil {
+		api.Close(file.Descriptor)
+		return nil, err
+	}
+	header, _ := View[ringHeader](ring.header, 0)
+	header.Size = uint64(size)
+	header.Version = ringVersion
+	atomic.StoreUint32(&header.Magic, ringMagic)
+	return ring, nil
+}
+
+// OpenRing opens the [Ring] in the memory file fd, as created by [NewRing],
+// usually in another process. The ring takes ownership of fd, which is closed
+// if the ring cannot be opened.
+func OpenRing(api *API, fd FileDescriptor) (*Ring, error) {
+	var page = syscall.Getpagesize()
+	stat, err := api.StatFile(fd)
+	if err != nil {
+		api.Close(fd)
+		return nil, err
+	}
+	var size = int(stat.Size) - page
+	if size <= 0 || size%page != 0 {
+		api.Close(fd)
+		return nil, new(MapError).Types().Invalid
+	}
+	ring, err := mapRing(api, fd, size)
+	if err != nil {
+		api.Close(fd)
+		return nil, err
+	}
+	header, _ := View[ringHeader](ring.header, 0)
+	if atomic.LoadUint32(&header.Magic) != ringMagic || header.Version != ringVersion || header.Size != uint64(size) {
+		ring.unmap()
+		api.Close(fd)
+		return nil, new(MapError).Types().Invalid
+	}
+	return ring, nil
+}
+
+// mapRing maps the header of the ring and then its data twice, into a
+// reserved region of twice its size.
+func mapRing(api *API, fd FileDescriptor, size int) (*Ring, error) {
+	var page = syscall.Getpagesize()
+	var rw = MemoryAllowReads | MemoryAllowWrites
+	var ring = &Ring{linux: api, fd: fd, size: uint64(size)}
+	var err error
+	if ring.header, err = api.MapIntoMemory(nil, page, rw, MapShared, 0, fd, 0); err != nil {
+		return nil, err
+	}
+	reserved, err := api.MapIntoMemory(nil, 2*size, MemoryNotAccessible, MapPrivate, MapAnonymous, -1, 0)
+	if err != nil {
+		ring.unmap()
+		return nil, err
+	}
+	for i := range ring.data {
+		var addr = unsafe.Add(reserved.UnsafePointer(), i*size)
+		if ring.data[i], err = api.MapIntoMemory(addr, size, rw, MapShared, MapExactAddress, fd, uintptr(page)); err != nil {
+			break
+		}
+		if ring.data[i].UnsafePointer() != addr {
+			err = new(MapError).Types().Invalid
+			break
+		}
+	}
+	if err != nil {
+		ring.unmap()
+		reserved.Close()
+		return nil, err
+	}
+	ring.buf = unsafe.Slice((*byte)(reserved.UnsafePointer()), 2*size)
+	ring.head, _ = AtomicUint64(ring.header, int(unsafe.Offsetof(ringHeader{}.Head)))
+	ring.tail, _ = AtomicUint64(ring.header, int(unsafe.Offsetof(ringHeader{}.Tail)))
+	ring.closed, _ = AtomicUint32(ring.header, int(unsafe.Offsetof(ringHeader{}.Closed)))
+	return ring, nil
+}
+
+// File of the ring, to pass to another process, see [OpenRing].
+func (r *Ring) File() FileDescriptor { return r.fd }
+
+// Len returns the number of bytes that can be read from the ring.
+func (r *Ring) Len() int { return int(r.head.Load() - r.tail.Load()) }
+
+// Cap returns the number of bytes that the ring can hold.
+func (r *Ring) Cap() int { return int(r.size) }
+
+// Read implements [io.Reader], returns [io.EOF] once the ring is empty and
+// closed for writing.
+func (r *Ring) Read(p []byte) (int, error) {
+	var tail = r.tail.Load()
+	var available = r.head.Load() - tail
+	if available == 0 {
+		if r.closed.Load() != 0 && r.head.Load() == tail {
+			return 0, io.EOF
+		}
+		if len(p) == 0 {
+			return 0, nil
+		}
+		return 0, new(ReadError).Types().WouldBlock
+	}
+	var start = tail % r.size
+	var n = copy(p, r.buf[start:start+available])
+	r.tail.Store(tail + uint64(n))
+	return n, nil
+}
+
+// Write implements [io.Writer], writes as much of p as fits in the ring.
+func (r *Ring) Write(p []byte) (int, error) {
+	if r.closed.Load() != 0 {
+		return 0, new(WriteError).Types().BrokenPipe
+	}
+	var head = r.head.Load()
+	var free = r.size - (head - r.tail.Load())
+	var start = head % r.size
+	var n = copy(r.buf[start:start+free], p)
+	r.head.Store(head + uint64(n))
+	if n < len(p) {
+		return n, new(WriteError).Types().WouldBlock
+	}
+	return n, nil
+}
+
+// CloseWrite marks the ring as closed for writing, so that it reads as
+// [io.EOF] once it is empty.
+func (r *Ring) CloseWrite() error {
+	r.closed.Store(1)
+	return nil
+}
+
+// Close unmaps the ring and closes its file, the ring remains available to
+// other processes that have it open.
+func (r *Ring) Close() error {
+	if r.done.Swap(true) {
+		return nil
+	}
+	r.unmap()
+	return r.linux.Close(r.fd)
+}
+
+func (r *Ring) unmap() {
+	for _, mapped := range []MappedMemory{r.header, r.data[0], r.data[1]} {
+		if mapped != nil {
+			mapped.Close()
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package linux_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"syscall"
+	"testing"
+
+	"verbose.style/linux"
+)
+
+func TestRing(t *testing.T) {
+	var api = linux.Native()
+	ring, err := linux.NewRing(api, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ring.Close()
+	var size = ring.Cap()
+	if size != os.Getpagesize() {
+		t.Fatalf("expected a page, got %d", size)
+	}
+	var buf = make([]byte, size)
+	if _, err := ring.Read(buf); !errors.Is(err, new(linux.ReadError).Types().WouldBlock) {
+		t.Fatalf("expected would block, got %v", err)
+	}
+	// move the ring close to its end, so that the next write wraps around.
+	if n, err := ring.Write(make([]byte, size-3)); err != nil || n != size-3 {
+		t.Fatal(n, err)
+	}
+	if n, err := ring.Read(buf); err != nil || n != size-3 {
+		t.Fatal(n, err)
+	}
+	var message = bytes.Repeat([]byte("wrap"), size/4+1)
+	n, err := ring.Write(message)
+	if n != size || !errors.Is(err, new(linux.WriteError).Types().WouldBlock) {
+		t.Fatalf("expected a partial write, got %d %v", n, err)
+	}
+	if ring.Len() != size {
+		t.Fatalf("expected a full ring, got %d", ring.Len())
+	}
+	if n, err := ring.Read(buf); err != nil || n != size || !bytes.Equal(buf, message[:size]) {
+		t.Fatalf("read %d %v", n, err)
+	}
+	ring.Write([]byte("end"))
+	ring.CloseWrite()
+	if _, err := ring.Write([]byte("more")); !errors.Is(err, new(linux.WriteError).Types().BrokenPipe) {
+		t.Fatalf("expected broken pipe, got %v", err)
+	}
+	if n, err := ring.Read(buf); err != nil || string(buf[:n]) != "end" {
+		t.Fatalf("read %q %v", buf[:n], err)
+	}
+	if _, err := ring.Read(buf); err != io.EOF {
+		t.Fatalf("expected EOF, got %v", err)
+	}
+	if _, err := linux.NewRing(linux.Memory(), 1); !errors.Is(err, new(linux.MapError).Types().Invalid) {
+		t.Fatalf("expected rings to be unsupported by memory, got %v", err)
+	}
+}
+
+func TestOpenRingInvalid(t *testing.T) {
+	var api = linux.Native()
+	file, err := api.CreateMemoryFile("ring", linux.MemoryFileCloseOnExecute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := linux.OpenRing(api, file.Descriptor); !errors.Is(err, new(linux.MapError).Types().Invalid) {
+		t.Fatalf("expected invalid for an empty file, got %v", err)
+	}
+	if err := api.Close(file.Descriptor); !errors.Is(err, new(linux.CloseError).Types().BadFile) {
+		t.Fatalf("expected the ring to close the file, got %v", err)
+	}
+}
+
+func TestRingAcrossProcesses(t *testing.T) {
+	if os.Getenv("LINUX_TEST_RING") != "" {
+		ring, err := linux.OpenRing(linux.Native(), 3)
+		if err != nil {
+			os.Exit(1)
+		}
+		for _, message := range []string{"hello ", "from ", "the child"} {
+			if _, err := ring.Write([]byte(message)); err != nil {
+				os.Exit(2)
+			}
+		}
+		ring.CloseWrite()
+		os.Exit(0)
+	}
+	var api = linux.Native()
+	ring, err := linux.NewRing(api, 4096)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ring.Close()
+	fd, err := syscall.Dup(int(ring.File()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cmd = exec.Command(os.Args[0], "-test.run=^TestRingAcrossProcesses$")
+	cmd.Env = append(os.Environ(), "LINUX_TEST_RING=1")
+	cmd.ExtraFiles = []*os.File{os.NewFile(uintptr(fd), "ring")}
+	if err := cmd.Run(); err != nil {
+		t.Fatal(err)
+	}
+	cmd.ExtraFiles[0].Close()
+	data, err := io.ReadAll(ring)
+	if err != nil || string(data) != "hello from the child" {
+		t.Fatalf("read %q %v", data, err)
+	}
+}
@@ -0,0 +1,84 @@
+package linux
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// flagName is the name of a flag or enum value, generated by internal/flagsgen.
+type flagName[T ~int | ~int16 | ~uint32] struct {
+	name  string
+	value T
+}
+
+// formatFlags formats value as its names separated by '|', names that span
+// multiple bits must be listed before the names of their individual bits. Bits
+// without a name are formatted in hex.
+func formatFlags[T ~int | ~int16 | ~uint32](value T, zero string, names []flagName[T]) string {
+	if value == 0 {
+		return zero
+	}
+	var s strings.Builder
+	for _, n := range names {
+		if n.value != 0 && value&n.value == n.value {
+			if s.Len() > 0 {
+				s.WriteByte('|')
+			}
+			s.WriteString(n.name)
+			value &^= n.value
+		}
+	}
+	if value != 0 {
+		if s.Len() > 0 {
+			s.WriteByte('|')
+		}
+		s.WriteString("0x" + strconv.FormatUint(uint64(value)&(1<<bitSize(value)-1), 16))
+	}
+	return s.String()
+}
+
+// formatEnum formats value as its name, or in decimal if it has none.
+func formatEnum[T ~int | ~int16 | ~uint32](value T, names []flagName[T]) string {
+	for _, n := range names {
+		if n.value == value {
+			return n.name
+		}
+	}
+	return strconv.FormatInt(int64(value), 10)
+}
+
+// parseFlags parses names or numbers separated by '|', as formatted by
+// [formatFlags].
+func parseFlags[T ~int | ~int16 | ~uint32](kind, s string, names []flagName[T]) (T, error) {
+	var value T
+	for _, part := range strings.Split(s, "|") {
+		v, err := parseEnum(kind, part, names)
+		if err != nil {
+			return 0, err
+		}
+		value |= v
+	}
+	return value, nil
+}
+
+// parseEnum parses a name or a number, as formatted by [formatEnum].
+func parseEnum[T ~int | ~int16 | ~uint32](kind, s string, names []flagName[T]) (T, error) {
+	s = strings.TrimSpace(s)
+	for _, n := range names {
+		if n.name == s {
+			return n.value, nil
+		}
+	}
+	v, err := strconv.ParseInt(s, 0, bitSize(T(0)))
+	if err != nil {
+		if u, uerr := strconv.ParseUint(s, 0, bitSize(T(0))); uerr == nil {
+			return T(u), nil
+		}
+		return 0, fmt.Errorf("linux: invalid %s %q", kind, s)
+	}
+	return T(v), nil
+}
+
+func bitSize[T ~int | ~int16 | ~uint32](value T) int { return 8 * int(unsafe.Sizeof(value)) }
@@ -0,0 +1,95 @@
+package linux_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"verbose.style/linux"
+)
+
+func TestFlagsString(t *testing.T) {
+	for _, test := range []struct {
+		value interface{ String() string }
+		text  string
+	}{
+		{linux.FileCreateIfNeeded | linux.FileAssertCreation, "FileCreateIfNeeded|FileAssertCreation"},
+		{linux.FileTemporaryInside, "FileTemporaryInside"},
+		{linux.FileTemporaryInside | linux.FileCloseOnExecute, "FileTemporaryInside|FileCloseOnExecute"},
+		{linux.FileAssertDirectory, "FileAssertDirectory"},
+		{linux.FileSync, "FileSync"},
+		{linux.FileSyncData | linux.FileAppend, "FileAppend|FileSyncData"},
+		{linux.FileStatusFlags(0), "0"},
+		{linux.MemoryNotAccessible, "MemoryNotAccessible"},
+		{linux.MemoryAllowReads | linux.MemoryAllowWrites, "MemoryAllowReads|MemoryAllowWrites"},
+		{linux.MapAnonymous | linux.MapHuge2MB, "MapHuge2MB|MapAnonymous"},
+		{linux.Map(0), "0"},
+		{linux.PollHasReadAvailable | 0x4000, "PollHasReadAvailable|0x4000"},
+		{linux.Poll(-0x8000), "0x8000"},
+		{linux.MapPrivate, "MapPrivate"},
+		{linux.SeekData, "SeekData"},
+		{linux.Seek(9), "9"},
+		{linux.FileAccessReadWrite, "FileAccessReadWrite"},
+	} {
+		if text := test.value.String(); text != test.text {
+			t.Errorf("%#v is %q, expected %q", test.value, text, test.text)
+		}
+	}
+}
+
+func TestParseFlags(t *testing.T) {
+	for _, value := range []linux.FileCreationFlags{
+		0,
+		linux.FileCreateIfNeeded | linux.FileAssertCreation,
+		linux.FileTemporaryInside | linux.FileTruncatedToZero,
+		linux.FileCloseOnExecute | 0x4000000,
+	} {
+		parsed, err := linux.ParseFileCreationFlags(value.String())
+		if err != nil || parsed != value {
+			t.Errorf("%q parsed as %v, %v", value.String(), parsed, err)
+		}
+	}
+	if poll, err := linux.ParsePoll(" PollHasReadAvailable | PollHasError "); err != nil || poll != linux.PollHasReadAvailable|linux.PollHasError {
+		t.Error(poll, err)
+	}
+	if seek, err := linux.ParseSeek("2"); err != nil || seek != linux.SeekRelativeToEnd {
+		t.Error(seek, err)
+	}
+	for _, text := range []string{"", "FileAppend|", "MapShared", "0xzz"} {
+		if _, err := linux.ParseFileStatusFlags(text); err == nil {
+			t.Errorf("%q parsed without error", text)
+		}
+	}
+}
+
+func TestFlagsJSON(t *testing.T) {
+	type config struct {
+		Flags  linux.FileCreationFlags
+		Status linux.FileStatusFlags
+		Mode   linux.FileAccessMode
+		Prot   linux.MemoryProtection
+		Map    linux.Map
+		Type   linux.MapType
+		Poll   linux.Poll
+	}
+	var input = config{
+		Flags:  linux.FileCreateIfNeeded | linux.FileAssertCreation,
+		Status: linux.FileSync | linux.FileNonBlocking,
+		Mode:   linux.FileAccessWriteOnly,
+		Prot:   linux.MemoryAllowReads,
+		Map:    linux.MapAnonymous | linux.MapPopulate,
+		Type:   linux.MapShared,
+		Poll:   linux.PollHasWriteAvailable,
+	}
+	data, err := json.Marshal(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const expected = `{"Flags":"FileCreateIfNeeded|FileAssertCreation","Status":"FileSync|FileNonBlocking","Mode":"FileAccessWriteOnly","Prot":"MemoryAllowReads","Map":"MapAnonymous|MapPopulate","Type":"MapShared","Poll":"PollHasWriteAvailable"}`
+	if string(data) != expected {
+		t.Fatal(string(data))
+	}
+	var output config
+	if err := json.Unmarshal(data, &output); err != nil || output != input {
+		t.Fatal(output, err)
+	}
+}
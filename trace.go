@@ -0,0 +1,214 @@
+package linux
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// Tracer receives a [Call] for every call made through an [API] returned by
+// [Trace]. Calls may be traced concurrently.
+type Tracer interface {
+	Trace(call Call)
+}
+
+// Call to an [API] function, as reported to a [Tracer].
+type Call struct {
+	Name     string        // name of the [API] function that was called.
+	Args     []any         // arguments, as they were after the call returned.
+	Results  []any         // results, excluding the error.
+	Err      error         // error returned by the call, if any.
+	Duration time.Duration // time spent within the call.
+}
+
+// Trace returns an [API] that reports every call made through it to sink,
+// before returning the results of the corresponding call to api. Files opened
+// through the returned [API] are traced as well.
+func Trace(api *API, sink Tracer) *API {
+	var traced = new(API)
+	var inner = reflect.ValueOf(api).Elem()
+	var outer = reflect.ValueOf(traced).Elem()
+	for i := range outer.NumField() {
+		var field = outer.Type().Field(i)
+		var fn = inner.Field(i)
+		if fn.IsNil() {
+			continue
+		}
+		outer.Field(i).Set(reflect.MakeFunc(field.Type, func(args []reflect.Value) []reflect.Value {
+			var start = time.Now()
+			var results = fn.Call(args)
+			var call = Call{Name: field.Name, Duration: time.Since(start)}
+			for _, arg := range args {
+				call.Args = append(call.Args, arg.Interface())
+			}
+			for i, result := range results {
+				if result.Type() == reflect.TypeFor[File]() {
+					results[i] = rebind(result, api, traced)
+				}
+				if result.Type() == reflect.TypeFor[error]() {
+					call.Err, _ = result.Interface().(error)
+					continue
+				}
+				call.Results = append(call.Results, results[i].Interface())
+			}
+			sink.Trace(call)
+			return results
+		}))
+	}
+	return traced
+}
+
+// rebind a [File] result that was opened by from, so that subsequent file
+// operations go through to.
+func rebind(file reflect.Value, from, to *API) reflect.Value {
+	if file.FieldByName("Linux").Interface() != from {
+		return file
+	}
+	var value = reflect.New(file.Type()).Elem()
+	value.FieldByName("Linux").Set(reflect.ValueOf(to))
+	value.FieldByName("Descriptor").Set(file.FieldByName("Descriptor"))
+	return value
+}
+
+// TextTracer returns a [Tracer] that writes each call to w as a line of text
+// in the style of strace, for example:
+//
+//	Open("data", FileAccessReadOnly, FileCloseOnExecute, 0, 0) = 3 <8.1µs>
+//	Read(3, "", 4096) = 0 ReadError.WouldBlock (resource temporarily unavailable) <1.2µs>
+func TextTracer(w io.Writer) Tracer {
+	return &textTracer{w: w}
+}
+
+type textTracer struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (t *textTracer) Trace(call Call) {
+	var line strings.Builder
+	line.WriteString(call.Name)
+	line.WriteByte('(')
+	for i, arg := range call.Args {
+		if i > 0 {
+			line.WriteString(", ")
+		}
+		line.WriteString(traceArg(call, i, arg))
+	}
+	line.WriteString(") =")
+	for _, result := range call.Results {
+		line.WriteByte(' ')
+		line.WriteString(traceValue(result))
+	}
+	if call.Err != nil {
+		fmt.Fprintf(&line, " %s (%s)", traceError(call.Err), call.Err)
+	}
+	fmt.Fprintf(&line, " <%v>\n", call.Duration)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	io.WriteString(t.w, line.String())
+}
+
+// JSONTracer returns a [Tracer] that writes each call to w as a line of JSON
+// (JSON Lines), with the arguments and results decoded as they would be by
+// [TextTracer].
+func JSONTracer(w io.Writer) Tracer {
+	return &jsonTracer{w: w}
+}
+
+type jsonTracer struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (t *jsonTracer) Trace(call Call) {
+	var line struct {
+		Call     string   `json:"call"`
+		Args     []string `json:"args"`
+		Results  []string `json:"results"`
+		Error    string   `json:"error,omitempty"`
+		Message  string   `json:"message,omitempty"`
+		Duration int64    `json:"duration_ns"`
+	}
+	line.Call = call.Name
+	line.Args = make([]string, len(call.Args))
+	for i, arg := range call.Args {
+		line.Args[i] = traceArg(call, i, arg)
+	}
+	line.Results = make([]string, len(call.Results))
+	for i, result := range call.Results {
+		line.Results[i] = traceValue(result)
+	}
+	if call.Err != nil {
+		line.Error = traceError(call.Err)
+		line.Message = call.Err.Error()
+	}
+	line.Duration = int64(call.Duration)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	json.NewEncoder(t.w).Encode(line)
+}
+
+// traceArg formats the i'th argument of call, buffers filled in by [API.Read]
+// and [API.ReadDirectory] are limited to the number of bytes read.
+func traceArg(call Call, i int, arg any) string {
+	if buf, ok := arg.([]byte); ok && (call.Name == "Read" || call.Name == "ReadDirectory") && len(call.Results) > 0 {
+		if n, ok := call.Results[0].(Bytes); ok && n >= 0 && n <= Bytes(len(buf)) {
+			return traceBytes(buf[:n], len(buf))
+		}
+	}
+	return traceValue(arg)
+}
+
+func traceBytes(buf []byte, size int) string {
+	const limit = 32
+	if len(buf) > limit {
+		return strconv.Quote(string(buf[:limit])) + "..., " + strconv.Itoa(size)
+	}
+	return strconv.Quote(string(buf)) + ", " + strconv.Itoa(size)
+}
+
+func traceError(err error) string {
+	if named, ok := err.(interface{ name() string }); ok {
+		return reflect.TypeOf(err).Name() + "." + named.name()
+	}
+	return fmt.Sprintf("%T", err)
+}
+
+func traceValue(value any) string {
+	switch v := value.(type) {
+	case Path:
+		return strconv.Quote(string(v))
+	case []byte:
+		return traceBytes(v, len(v))
+	case FilePermissions:
+		return "0" + strconv.FormatUint(uint64(v), 8)
+	case []FileToPoll:
+		var s strings.Builder
+		s.WriteByte('[')
+		for i, file := range v {
+			if i > 0 {
+				s.WriteString(", ")
+			}
+			fmt.Fprintf(&s, "{File=%d, Notify=%s, Result=%s}", file.File, traceValue(file.Notify), traceValue(file.Result))
+		}
+		s.WriteByte(']')
+		return s.String()
+	case File:
+		return strconv.Itoa(int(v.Descriptor))
+	case MappedMemory:
+		return fmt.Sprintf("%p, %d", v.UnsafePointer(), v.Len())
+	case unsafe.Pointer:
+		return fmt.Sprintf("%p", v)
+	case time.Duration:
+		return v.String()
+	case nil:
+		return "nil"
+	}
+	return fmt.Sprint(value)
+}
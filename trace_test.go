@@ -0,0 +1,56 @@
+package linux_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"verbose.style/linux"
+)
+
+func TestTrace(t *testing.T) {
+	var text, lines bytes.Buffer
+	var Linux = linux.Trace(linux.Trace(linux.Memory(), linux.TextTracer(&text)), linux.JSONTracer(&lines))
+
+	f, err := Linux.Open("/file", linux.FileAccessWriteOnly, linux.FileCreateIfNeeded|linux.FileTruncatedToZero, linux.FileSync|linux.FileAppend, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Read(make([]byte, 4)); err == nil {
+		t.Fatal("expected error")
+	}
+
+	var expect = []string{
+		`Open("/file", FileAccessWriteOnly, FileCreateIfNeeded|FileTruncatedToZero, FileSync|FileAppend, 0644) = 3 <`,
+		`Write(3, "hello", 5) = 5 <`,
+		`Read(3, "", 4) = 0 ReadError.BadFile (bad file descriptor) <`,
+	}
+	var got = strings.Split(strings.TrimSpace(text.String()), "\n")
+	if len(got) != len(expect) {
+		t.Fatal(text.String())
+	}
+	for i := range expect {
+		if !strings.HasPrefix(got[i], expect[i]) {
+			t.Fatalf("%s\nexpected prefix\n%s", got[i], expect[i])
+		}
+	}
+
+	var call struct {
+		Call  string   `json:"call"`
+		Args  []string `json:"args"`
+		Error string   `json:"error"`
+	}
+	var decoder = json.NewDecoder(&lines)
+	for range 3 {
+		if err := decoder.Decode(&call); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if call.Call != "Read" || call.Error != "ReadError.BadFile" || call.Args[0] != "3" {
+		t.Fatal(call)
+	}
+}
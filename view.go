@@ -0,0 +1,121 @@
+package linux
+
+import (
+	"reflect"
+	"strconv"
+	"structs"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// ViewError is returned by [View], [SliceOf] and the atomic accessors, when
+// the mapped memory cannot be viewed as the requested type.
+type ViewError struct {
+	Type   reflect.Type // type that was requested.
+	Offset int          // offset into the mapped memory.
+	Count  int          // number of values requested.
+	Reason string       // why the memory cannot be viewed, for example "out of bounds".
+}
+
+func (e *ViewError) Error() string {
+	return "view " + strconv.Itoa(e.Count) + " " + e.Type.String() + " at " + strconv.Itoa(e.Offset) + ": " + e.Reason
+}
+
+// View returns a pointer to the T at offset in m, which remains valid only
+// for as long as m is mapped. T must be a fixed-size number, an array of
+// them, or a struct with a [structs.HostLayout] field (and so on for its
+// fields) so that its layout matches the host's C ABI. The value must fit in
+// m and be aligned for T.
+func View[T any](m MappedMemory, offset int) (*T, error) {
+	ptr, err := view(m, offset, 1, reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+	return (*T)(ptr), nil
+}
+
+// SliceOf returns count consecutive values of T starting at offset in m, with
+// the same requirements as [View].
+func SliceOf[T any](m MappedMemory, offset, count int) ([]T, error) {
+	ptr, err := view(m, offset, count, reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+	return unsafe.Slice((*T)(ptr), count), nil
+}
+
+// AtomicUint32 returns the uint32 at offset in m, for atomic access, see [View].
+func AtomicUint32(m MappedMemory, offset int) (*atomic.Uint32, error) {
+	ptr, err := view(m, offset, 1, reflect.TypeFor[uint32]())
+	if err != nil {
+		return nil, err
+	}
+	return (*atomic.Uint32)(ptr), nil
+}
+
+// AtomicUint64 returns the uint64 at offset in m, for atomic access, which
+// must be aligned to 8 bytes, even on 32-bit platforms, see [View].
+func AtomicUint64(m MappedMemory, offset int) (*atomic.Uint64, error) {
+	ptr, err := view(m, offset, 1, reflect.TypeFor[uint64]())
+	if err != nil {
+		return nil, err
+	}
+	if uintptr(ptr)%8 != 0 {
+		return nil, &ViewError{Type: reflect.TypeFor[uint64](), Offset: offset, Count: 1, Reason: "misaligned"}
+	}
+	return (*atomic.Uint64)(ptr), nil
+}
+
+// view checks that count values of the given type fit at offset in m.
+func view(m MappedMemory, offset, count int, rtype reflect.Type) (unsafe.Pointer, error) {
+	var fail = func(reason string) (unsafe.Pointer, error) {
+		return nil, &ViewError{Type: rtype, Offset: offset, Count: count, Reason: reason}
+	}
+	if !hostLayout(rtype) {
+		return fail("type does not have a host layout")
+	}
+	var size = int(rtype.Size())
+	if offset < 0 || count < 0 || offset > m.Len() || (size > 0 && count > (m.Len()-offset)/size) {
+		return fail("out of bounds")
+	}
+	var ptr = unsafe.Add(m.UnsafePointer(), offset)
+	if uintptr(ptr)%uintptr(rtype.Align()) != 0 {
+		return fail("misaligned")
+	}
+	return ptr, nil
+}
+
+// hostLayouts caches the result of [hostLayout] for each type.
+var hostLayouts sync.Map
+
+// hostLayout reports whether values of the given type can be safely placed
+// in mapped memory, as they have a host layout and contain no pointers.
+func hostLayout(rtype reflect.Type) bool {
+	if ok, cached := hostLayouts.Load(rtype); cached {
+		return ok.(bool)
+	}
+	var ok bool
+	switch rtype.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		ok = true
+	case reflect.Array:
+		ok = hostLayout(rtype.Elem())
+	case reflect.Struct:
+		ok = true
+		var marked bool
+		for i := range rtype.NumField() {
+			var field = rtype.Field(i)
+			if field.Type == reflect.TypeFor[structs.HostLayout]() {
+				marked = true
+			} else if !hostLayout(field.Type) {
+				ok = false
+			}
+		}
+		ok = ok && marked
+	}
+	hostLayouts.Store(rtype, ok)
+	return ok
+}
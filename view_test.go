@@ -0,0 +1,98 @@
+package linux_test
+
+import (
+	"errors"
+	"structs"
+	"testing"
+
+	"verbose.style/linux"
+)
+
+type viewHeader struct {
+	_ structs.HostLayout
+
+	Magic   uint32
+	Version uint16
+	Flags   [2]uint8
+	Length  uint64
+}
+
+type viewUnmarked struct {
+	Magic uint32
+}
+
+type viewPointer struct {
+	_ structs.HostLayout
+
+	Next *viewPointer
+}
+
+func TestView(t *testing.T) {
+	for name, api := range map[string]*linux.API{
+		"Native": linux.Native(),
+		"Memory": linux.Memory(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			m, err := api.MapIntoMemory(nil, 4096, linux.MemoryAllowReads|linux.MemoryAllowWrites, linux.MapPrivate, linux.MapAnonymous, -1, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer m.Close()
+			header, err := linux.View[viewHeader](m, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			header.Magic = 0xfeedface
+			header.Length = 42
+			var buf [4]byte
+			if _, err := m.ReadAt(buf[:], 0); err != nil || buf != [4]byte{0xce, 0xfa, 0xed, 0xfe} && buf != [4]byte{0xfe, 0xed, 0xfa, 0xce} {
+				t.Fatalf("read %x %v", buf, err)
+			}
+			values, err := linux.SliceOf[uint32](m, 16, 1020)
+			if err != nil {
+				t.Fatal(err)
+			}
+			values[1019] = 7
+			if _, err := linux.SliceOf[uint32](m, 16, 1021); !isViewError(err, "out of bounds") {
+				t.Fatalf("expected out of bounds, got %v", err)
+			}
+			if _, err := linux.View[uint64](m, 4096); !isViewError(err, "out of bounds") {
+				t.Fatalf("expected out of bounds, got %v", err)
+			}
+			if _, err := linux.View[uint32](m, -4); !isViewError(err, "out of bounds") {
+				t.Fatalf("expected out of bounds, got %v", err)
+			}
+			if _, err := linux.View[uint32](m, 2); !isViewError(err, "misaligned") {
+				t.Fatalf("expected misaligned, got %v", err)
+			}
+			if _, err := linux.View[viewUnmarked](m, 0); !isViewError(err, "type does not have a host layout") {
+				t.Fatalf("expected layout error, got %v", err)
+			}
+			if _, err := linux.View[viewPointer](m, 0); !isViewError(err, "type does not have a host layout") {
+				t.Fatalf("expected layout error, got %v", err)
+			}
+			counter, err := linux.AtomicUint64(m, 8)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if counter.Add(1) != 43 {
+				t.Fatal("atomic does not alias the view")
+			}
+			if _, err := linux.AtomicUint64(m, 4); !isViewError(err, "misaligned") {
+				t.Fatalf("expected misaligned, got %v", err)
+			}
+			flag, err := linux.AtomicUint32(m, 4)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !flag.CompareAndSwap(0, 1) {
+				t.Fatal("compare and swap failed")
+			}
+		})
+	}
+}
+
+func isViewError(err error, reason string) bool {
+	var view *linux.ViewError
+	return errors.As(err, &view) && view.Reason == reason
+}